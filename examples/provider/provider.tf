@@ -1,3 +1,3 @@
-provider "scaffolding" {
-  # example configuration here
+provider "devops-bootcamp" {
+  endpoint = "http://localhost:8080"
 }
@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    devops-bootcamp = {
+      source = "liatr.io/terraform/devops-bootcamp"
+    }
+  }
+}
+
+provider "devops-bootcamp" {
+  endpoint = "http://localhost:8080"
+}
+
+resource "devops-bootcamp_engineer" "Ryan" {
+  name  = "Ryan"
+  email = "ryan@example.com"
+}
+
+resource "devops-bootcamp_engineer" "Ava" {
+  name  = "Ava"
+  email = "ava@example.com"
+}
+
+resource "devops-bootcamp_dev" "backend" {
+  name = "backend"
+  engineers = [
+    { id = devops-bootcamp_engineer.Ryan.id },
+  ]
+}
+
+resource "devops-bootcamp_ops" "platform" {
+  name = "platform"
+  engineers = [
+    { id = devops-bootcamp_engineer.Ava.id },
+  ]
+}
+
+resource "devops-bootcamp_devops" "team" {
+  dev = [
+    { id = devops-bootcamp_dev.backend.id },
+  ]
+  ops = [
+    { id = devops-bootcamp_ops.platform.id },
+  ]
+}
+
+output "devops_team" {
+  value = resource.devops-bootcamp_devops.team
+}
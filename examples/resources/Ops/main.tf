@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    devops-bootcamp = {
+      source = "liatr.io/terraform/devops-bootcamp"
+    }
+  }
+}
+
+provider "devops-bootcamp" {
+  endpoint = "http://localhost:8080"
+}
+
+resource "devops-bootcamp_engineer" "Ryan" {
+  name  = "Ryan"
+  email = "ryan@example.com"
+}
+
+resource "devops-bootcamp_ops" "platform" {
+  name = "platform"
+  engineers = [
+    { id = devops-bootcamp_engineer.Ryan.id },
+  ]
+}
+
+output "devops_ops" {
+  value = resource.devops-bootcamp_ops.platform
+}
@@ -0,0 +1,133 @@
+package bootcampapi
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// Fault describes how the server misbehaves for the requests it is injected
+// for. Latency is applied first, then at most one of the other behaviors in
+// the order Reset, Status, Truncate.
+type Fault struct {
+	// Latency delays the response, or the other behaviors of the fault.
+	Latency time.Duration
+	// Reset closes the connection without writing a response.
+	Reset bool
+	// Status responds with this status code and Body instead of serving the
+	// request.
+	Status int
+	Body   string
+	// Truncate serves the request but only writes the first half of the
+	// response body, leaving malformed JSON.
+	Truncate bool
+	// Times is the number of requests the fault applies to before it is
+	// removed. Zero applies it until ClearFaults is called.
+	Times int
+}
+
+type injectedFault struct {
+	Fault
+
+	method string
+	prefix string
+}
+
+// InjectFault makes the server apply fault to requests with method, or any
+// method when empty, whose path starts with prefix. Faults are matched in the
+// order they were injected.
+func (s *Server) InjectFault(method string, prefix string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &injectedFault{Fault: fault, method: method, prefix: prefix})
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching r, counting it against the
+// fault's Times.
+func (s *Server) takeFault(r *http.Request) *injectedFault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if fault.method != "" && fault.method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.prefix) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+func (f *injectedFault) apply(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case f.Reset:
+		reset(w)
+	case f.Status != 0:
+		w.WriteHeader(f.Status)
+		_, _ = w.Write([]byte(f.Body))
+	case f.Truncate:
+		recorder := httptest.NewRecorder()
+		next(recorder, r)
+
+		for key, values := range recorder.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(recorder.Code)
+
+		body := recorder.Body.Bytes()
+		_, _ = w.Write(body[:len(body)/2])
+	default:
+		next(w, r)
+	}
+}
+
+// reset closes the connection of w, with a TCP reset where possible.
+func reset(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("bootcampapi: connection resets need a ResponseWriter that supports hijacking")
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(err)
+	}
+
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+
+	_ = conn.Close()
+}
@@ -0,0 +1,62 @@
+package bootcampapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServerFaults(t *testing.T) {
+	api := NewServer()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	api.InjectFault(http.MethodGet, "/engineers", Fault{Status: http.StatusServiceUnavailable, Body: "down", Times: 1})
+
+	if status := do(t, server, http.MethodGet, "/engineers", nil, nil); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the injected status, got %d", status)
+	}
+
+	// The fault only applied once
+	if status := do(t, server, http.MethodGet, "/engineers", nil, nil); status != http.StatusOK {
+		t.Fatalf("expected the fault to be removed, got %d", status)
+	}
+
+	api.InjectFault("", "/version", Fault{Truncate: true})
+
+	res, err := server.Client().Get(server.URL + "/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != `{"version"` {
+		t.Fatalf("expected a truncated body, got %q", body)
+	}
+
+	api.ClearFaults()
+	api.InjectFault(http.MethodPost, "/dev", Fault{Reset: true})
+
+	res, err = server.Client().Post(server.URL+"/dev", "application/json", nil)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected the connection to be reset")
+	}
+
+	api.ClearFaults()
+	api.InjectFault(http.MethodGet, "/dev", Fault{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/dev", nil)
+	res, err = server.Client().Do(req)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+}
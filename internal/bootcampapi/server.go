@@ -0,0 +1,541 @@
+// Package bootcampapi is an in-memory implementation of the DevOps bootcamp
+// API, serving the same routes the provider's Client calls. It lets the
+// acceptance tests run without an external API:
+//
+//	server := httptest.NewServer(bootcampapi.NewServer())
+//	defer server.Close()
+package bootcampapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Version is reported by the /version route.
+const Version = "1.0.0"
+
+// group is a developer or operations group, which only differ in their route.
+type group struct {
+	Id      string
+	Name    string
+	Members []string
+}
+
+// devops pairs developer and operations groups by id.
+type devops struct {
+	Id   string
+	Devs []string
+	Ops  []string
+}
+
+// Server is an http.Handler serving the bootcamp API from memory. The zero
+// value is not usable, create one with NewServer.
+type Server struct {
+	mu sync.Mutex
+
+	faults []*injectedFault
+
+	engineers *collection[devops_resource.Engineer]
+	devs      *collection[group]
+	ops       *collection[group]
+	devops    *collection[devops]
+}
+
+// NewServer returns an empty Server.
+func NewServer() *Server {
+	return &Server{
+		engineers: newCollection[devops_resource.Engineer](),
+		devs:      newCollection[group](),
+		ops:       newCollection[group](),
+		devops:    newCollection[devops](),
+	}
+}
+
+// ServeHTTP applies any fault injected for the request with InjectFault and
+// otherwise serves it in the same way as the bootcamp API:
+//
+//	GET    /version
+//	GET    /{kind}
+//	POST   /{kind}
+//	GET    /{kind}/id/{id}
+//	GET    /{kind}/name/{name}
+//	PUT    /{kind}/{id}
+//	DELETE /{kind}/{id}
+//
+// where kind is engineers, dev, op or devops. devops has no name route.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fault := s.takeFault(r)
+
+	if fault == nil {
+		s.handle(w, r)
+		return
+	}
+
+	fault.apply(w, r, s.handle)
+}
+
+// handle serves r from memory.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(segments) == 1 && segments[0] == "version" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]string{"version": Version})
+		return
+	}
+
+	var routes kindRoutes
+	switch segments[0] {
+	case "engineers":
+		routes = engineerRoutes{s}
+	case "dev":
+		routes = groupRoutes{s.devs, s}
+	case "op":
+		routes = groupRoutes{s.ops, s}
+	case "devops":
+		routes = devopsRoutes{s}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, routes.list())
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		routes.create(w, r)
+	case len(segments) == 3 && segments[1] == "id" && r.Method == http.MethodGet:
+		routes.getById(w, segments[2])
+	case len(segments) == 3 && segments[1] == "name" && r.Method == http.MethodGet:
+		routes.getByName(w, segments[2])
+	case len(segments) == 2 && r.Method == http.MethodPut:
+		routes.update(w, r, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		routes.delete(w, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// kindRoutes implements the routes of one kind of object. Handlers are called
+// with the server lock held.
+type kindRoutes interface {
+	list() any
+	create(w http.ResponseWriter, r *http.Request)
+	getById(w http.ResponseWriter, id string)
+	getByName(w http.ResponseWriter, name string)
+	update(w http.ResponseWriter, r *http.Request, id string)
+	delete(w http.ResponseWriter, id string)
+}
+
+type engineerRoutes struct {
+	s *Server
+}
+
+func (e engineerRoutes) list() any {
+	return e.s.engineers.all()
+}
+
+func (e engineerRoutes) create(w http.ResponseWriter, r *http.Request) {
+	var engineer devops_resource.Engineer
+	if !readJSON(w, r, &engineer) {
+		return
+	}
+
+	engineer.Id = newId()
+	e.s.engineers.put(engineer.Id, &engineer)
+
+	writeJSON(w, http.StatusCreated, engineer)
+}
+
+func (e engineerRoutes) getById(w http.ResponseWriter, id string) {
+	engineer, ok := e.s.engineers.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "engineer not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, engineer)
+}
+
+func (e engineerRoutes) getByName(w http.ResponseWriter, name string) {
+	for _, engineer := range e.s.engineers.all() {
+		if engineer.Name == name {
+			writeJSON(w, http.StatusOK, engineer)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "engineer not found")
+}
+
+func (e engineerRoutes) update(w http.ResponseWriter, r *http.Request, id string) {
+	engineer, ok := e.s.engineers.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "engineer not found")
+		return
+	}
+
+	var body devops_resource.Engineer
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	engineer.Name = body.Name
+	engineer.Email = body.Email
+
+	writeJSON(w, http.StatusOK, engineer)
+}
+
+func (e engineerRoutes) delete(w http.ResponseWriter, id string) {
+	engineer, ok := e.s.engineers.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "engineer not found")
+		return
+	}
+
+	e.s.engineers.remove(id)
+
+	// Groups reference engineers, so the engineer leaves every group
+	for _, groups := range []*collection[group]{e.s.devs, e.s.ops} {
+		for _, g := range groups.all() {
+			g.Members = without(g.Members, id)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, engineer)
+}
+
+// groupRoutes serves developer groups on /dev and operations groups on /op.
+// Both are rendered as devops_resource.Dev, which has the same JSON shape as
+// devops_resource.Ops.
+type groupRoutes struct {
+	groups *collection[group]
+	s      *Server
+}
+
+func (g groupRoutes) list() any {
+	groups := []*devops_resource.Dev{}
+	for _, stored := range g.groups.all() {
+		groups = append(groups, g.s.renderGroup(stored))
+	}
+
+	return groups
+}
+
+func (g groupRoutes) create(w http.ResponseWriter, r *http.Request) {
+	var body devops_resource.Dev
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	members, ok := g.s.engineerIds(w, body.Engineers)
+	if !ok {
+		return
+	}
+
+	stored := &group{Id: newId(), Name: body.Name, Members: members}
+	g.groups.put(stored.Id, stored)
+
+	writeJSON(w, http.StatusCreated, g.s.renderGroup(stored))
+}
+
+func (g groupRoutes) getById(w http.ResponseWriter, id string) {
+	stored, ok := g.groups.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "group not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, g.s.renderGroup(stored))
+}
+
+func (g groupRoutes) getByName(w http.ResponseWriter, name string) {
+	for _, stored := range g.groups.all() {
+		if stored.Name == name {
+			writeJSON(w, http.StatusOK, g.s.renderGroup(stored))
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "group not found")
+}
+
+func (g groupRoutes) update(w http.ResponseWriter, r *http.Request, id string) {
+	stored, ok := g.groups.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "group not found")
+		return
+	}
+
+	var body devops_resource.Dev
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	members, ok := g.s.engineerIds(w, body.Engineers)
+	if !ok {
+		return
+	}
+
+	stored.Name = body.Name
+	stored.Members = members
+
+	writeJSON(w, http.StatusOK, g.s.renderGroup(stored))
+}
+
+func (g groupRoutes) delete(w http.ResponseWriter, id string) {
+	stored, ok := g.groups.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "group not found")
+		return
+	}
+
+	g.groups.remove(id)
+
+	for _, pairing := range g.s.devops.all() {
+		pairing.Devs = without(pairing.Devs, id)
+		pairing.Ops = without(pairing.Ops, id)
+	}
+
+	writeJSON(w, http.StatusOK, g.s.renderGroup(stored))
+}
+
+type devopsRoutes struct {
+	s *Server
+}
+
+func (d devopsRoutes) list() any {
+	pairings := []*devops_resource.DevOps{}
+	for _, stored := range d.s.devops.all() {
+		pairings = append(pairings, d.s.renderDevOps(stored))
+	}
+
+	return pairings
+}
+
+func (d devopsRoutes) create(w http.ResponseWriter, r *http.Request) {
+	stored := &devops{Id: newId()}
+	if !d.read(w, r, stored) {
+		return
+	}
+
+	d.s.devops.put(stored.Id, stored)
+
+	writeJSON(w, http.StatusCreated, d.s.renderDevOps(stored))
+}
+
+func (d devopsRoutes) getById(w http.ResponseWriter, id string) {
+	stored, ok := d.s.devops.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "devops not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, d.s.renderDevOps(stored))
+}
+
+func (d devopsRoutes) getByName(w http.ResponseWriter, name string) {
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func (d devopsRoutes) update(w http.ResponseWriter, r *http.Request, id string) {
+	stored, ok := d.s.devops.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "devops not found")
+		return
+	}
+
+	if !d.read(w, r, stored) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, d.s.renderDevOps(stored))
+}
+
+func (d devopsRoutes) delete(w http.ResponseWriter, id string) {
+	stored, ok := d.s.devops.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "devops not found")
+		return
+	}
+
+	d.s.devops.remove(id)
+
+	writeJSON(w, http.StatusOK, d.s.renderDevOps(stored))
+}
+
+// read sets the groups of stored from the request body, which must only
+// reference existing groups.
+func (d devopsRoutes) read(w http.ResponseWriter, r *http.Request, stored *devops) bool {
+	var body devops_resource.DevOps
+	if !readJSON(w, r, &body) {
+		return false
+	}
+
+	devs := []string{}
+	for _, dev := range body.Devs {
+		if _, ok := d.s.devs.get(dev.Id); !ok {
+			writeError(w, http.StatusBadRequest, "dev "+dev.Id+" does not exist")
+			return false
+		}
+		devs = append(devs, dev.Id)
+	}
+
+	ops := []string{}
+	for _, op := range body.Ops {
+		if _, ok := d.s.ops.get(op.Id); !ok {
+			writeError(w, http.StatusBadRequest, "op "+op.Id+" does not exist")
+			return false
+		}
+		ops = append(ops, op.Id)
+	}
+
+	stored.Devs = devs
+	stored.Ops = ops
+
+	return true
+}
+
+// engineerIds returns the ids of engineers, writing a 400 response if one of
+// them does not exist.
+func (s *Server) engineerIds(w http.ResponseWriter, engineers []*devops_resource.Engineer) ([]string, bool) {
+	ids := []string{}
+	for _, engineer := range engineers {
+		if _, ok := s.engineers.get(engineer.Id); !ok {
+			writeError(w, http.StatusBadRequest, "engineer "+engineer.Id+" does not exist")
+			return nil, false
+		}
+		ids = append(ids, engineer.Id)
+	}
+
+	return ids, true
+}
+
+// renderGroup returns stored with the current details of its members.
+func (s *Server) renderGroup(stored *group) *devops_resource.Dev {
+	rendered := &devops_resource.Dev{
+		Id:        stored.Id,
+		Name:      stored.Name,
+		Engineers: []*devops_resource.Engineer{},
+	}
+
+	for _, id := range stored.Members {
+		if engineer, ok := s.engineers.get(id); ok {
+			member := *engineer
+			rendered.Engineers = append(rendered.Engineers, &member)
+		}
+	}
+
+	return rendered
+}
+
+// renderDevOps returns stored with the current details of its groups.
+func (s *Server) renderDevOps(stored *devops) *devops_resource.DevOps {
+	rendered := &devops_resource.DevOps{
+		Id:   stored.Id,
+		Devs: []*devops_resource.Dev{},
+		Ops:  []*devops_resource.Ops{},
+	}
+
+	for _, id := range stored.Devs {
+		if dev, ok := s.devs.get(id); ok {
+			rendered.Devs = append(rendered.Devs, s.renderGroup(dev))
+		}
+	}
+
+	for _, id := range stored.Ops {
+		if op, ok := s.ops.get(id); ok {
+			rendered.Ops = append(rendered.Ops, (*devops_resource.Ops)(s.renderGroup(op)))
+		}
+	}
+
+	return rendered
+}
+
+// collection stores objects by id, listing them in insertion order.
+type collection[T any] struct {
+	ids     []string
+	objects map[string]*T
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{objects: map[string]*T{}}
+}
+
+func (c *collection[T]) all() []*T {
+	all := make([]*T, 0, len(c.ids))
+	for _, id := range c.ids {
+		all = append(all, c.objects[id])
+	}
+
+	return all
+}
+
+func (c *collection[T]) get(id string) (*T, bool) {
+	object, ok := c.objects[id]
+
+	return object, ok
+}
+
+func (c *collection[T]) put(id string, object *T) {
+	if _, ok := c.objects[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+
+	c.objects[id] = object
+}
+
+func (c *collection[T]) remove(id string) {
+	delete(c.objects, id)
+	c.ids = without(c.ids, id)
+}
+
+// without returns ids with every occurrence of id removed.
+func without(ids []string, id string) []string {
+	kept := ids[:0]
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+
+	return kept
+}
+
+// newId returns a random identifier in the style of the API's object ids.
+func newId() string {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,132 @@
+package bootcampapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// do sends a request with body encoded as JSON to the server, decoding the
+// response into out when it is not nil, and returns the status code.
+func do(t *testing.T, server *httptest.Server, method string, path string, body any, out any) int {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, server.URL+path, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if out != nil && res.StatusCode < 300 {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return res.StatusCode
+}
+
+func TestServerVersion(t *testing.T) {
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+
+	var version map[string]string
+	if status := do(t, server, http.MethodGet, "/version", nil, &version); status != http.StatusOK || version["version"] != Version {
+		t.Fatalf("expected version %s, got %d %v", Version, status, version)
+	}
+}
+
+func TestServerEngineersAndGroups(t *testing.T) {
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+
+	var engineer devops_resource.Engineer
+	if status := do(t, server, http.MethodPost, "/engineers", devops_resource.Engineer{Name: "Ryan", Email: "ryan@example.com"}, &engineer); status != http.StatusCreated || engineer.Id == "" {
+		t.Fatalf("expected a created engineer, got %d %+v", status, engineer)
+	}
+
+	var dev devops_resource.Dev
+	body := devops_resource.Dev{Name: "Team", Engineers: []*devops_resource.Engineer{{Id: engineer.Id}}}
+	if status := do(t, server, http.MethodPost, "/dev", body, &dev); status != http.StatusCreated {
+		t.Fatalf("expected a created dev, got %d", status)
+	}
+
+	// Groups show the current details of their members
+	if status := do(t, server, http.MethodPut, "/engineers/"+engineer.Id, devops_resource.Engineer{Name: "Ryan", Email: "ryan@liatrio.com"}, nil); status != http.StatusOK {
+		t.Fatalf("expected the engineer to be updated, got %d", status)
+	}
+
+	if status := do(t, server, http.MethodGet, "/dev/name/Team", nil, &dev); status != http.StatusOK {
+		t.Fatalf("expected the dev by name, got %d", status)
+	}
+	if len(dev.Engineers) != 1 || dev.Engineers[0].Email != "ryan@liatrio.com" {
+		t.Fatalf("expected the updated engineer in the dev, got %+v", dev.Engineers)
+	}
+
+	// Groups only accept existing engineers
+	body = devops_resource.Dev{Name: "Team", Engineers: []*devops_resource.Engineer{{Id: "missing"}}}
+	if status := do(t, server, http.MethodPut, "/dev/"+dev.Id, body, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected an unknown engineer to be rejected, got %d", status)
+	}
+
+	// Deleted engineers leave their groups
+	if status := do(t, server, http.MethodDelete, "/engineers/"+engineer.Id, nil, nil); status != http.StatusOK {
+		t.Fatalf("expected the engineer to be deleted, got %d", status)
+	}
+
+	if status := do(t, server, http.MethodGet, "/engineers/id/"+engineer.Id, nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected the deleted engineer to be gone, got %d", status)
+	}
+
+	if status := do(t, server, http.MethodGet, "/dev/id/"+dev.Id, nil, &dev); status != http.StatusOK || len(dev.Engineers) != 0 {
+		t.Fatalf("expected the dev without engineers, got %d %+v", status, dev.Engineers)
+	}
+}
+
+func TestServerDevOps(t *testing.T) {
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+
+	var dev devops_resource.Dev
+	do(t, server, http.MethodPost, "/dev", devops_resource.Dev{Name: "Devs"}, &dev)
+
+	var ops devops_resource.Ops
+	do(t, server, http.MethodPost, "/op", devops_resource.Ops{Name: "Ops"}, &ops)
+
+	var devops devops_resource.DevOps
+	body := devops_resource.DevOps{Devs: []*devops_resource.Dev{{Id: dev.Id}}, Ops: []*devops_resource.Ops{{Id: ops.Id}}}
+	if status := do(t, server, http.MethodPost, "/devops", body, &devops); status != http.StatusCreated {
+		t.Fatalf("expected a created devops, got %d", status)
+	}
+
+	if len(devops.Devs) != 1 || devops.Devs[0].Name != "Devs" || len(devops.Ops) != 1 || devops.Ops[0].Name != "Ops" {
+		t.Fatalf("expected the groups to be rendered, got %+v", devops)
+	}
+
+	// Deleting a group removes it from the devops
+	do(t, server, http.MethodDelete, "/op/"+ops.Id, nil, nil)
+
+	if status := do(t, server, http.MethodGet, "/devops/id/"+devops.Id, nil, &devops); status != http.StatusOK || len(devops.Ops) != 0 {
+		t.Fatalf("expected the devops without ops, got %d %+v", status, devops.Ops)
+	}
+
+	if status := do(t, server, http.MethodGet, "/devops/name/anything", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected devops to have no name route, got %d", status)
+	}
+}
@@ -0,0 +1,279 @@
+// Package cassette records HTTP interactions into fixture files and replays
+// them, so API clients can be tested offline and deterministically.
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it forwards requests to a
+// real transport and writes every request and response to the cassette file
+// on Stop. In ModeReplay it answers requests from the file instead, in the
+// order they were recorded, and fails any request whose method, path or body
+// differs from the recording.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+// Request is a recorded request. Only the parts that identify the request are
+// kept, the host and headers such as credentials are left out.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   Body   `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int  `json:"status_code"`
+	Body       Body `json:"body,omitempty"`
+}
+
+// Body is a request or response body. JSON objects and arrays are stored as
+// JSON in the cassette file to keep it readable, anything else as a string.
+type Body string
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	trimmed := bytes.TrimSpace([]byte(b))
+
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, trimmed); err != nil {
+			return nil, err
+		}
+
+		return compact.Bytes(), nil
+	}
+
+	return json.Marshal(string(b))
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var body string
+		if err := json.Unmarshal(data, &body); err != nil {
+			return err
+		}
+
+		*b = Body(body)
+
+		return nil
+	}
+
+	*b = Body(data)
+
+	return nil
+}
+
+// Interaction is a request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the content of a fixture file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder records or replays the interactions of a cassette file.
+type Recorder struct {
+	mu sync.Mutex
+
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	cassette  Cassette
+	next      int
+}
+
+// New returns a Recorder for the cassette file at path. In ModeReplay the
+// file must exist. In ModeRecord requests are sent with transport, or
+// http.DefaultTransport when it is nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, transport: transport}
+
+	if mode == ModeRecord {
+		if r.transport == nil {
+			r.transport = http.DefaultTransport
+		}
+
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: Response{StatusCode: res.StatusCode, Body: Body(body)},
+	})
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	if r.next >= len(r.cassette.Interactions) {
+		return nil, fmt.Errorf("cassette %s has no interaction left for %s %s", r.path, recorded.Method, recorded.Path)
+	}
+
+	interaction := r.cassette.Interactions[r.next]
+
+	if err := interaction.Request.match(recorded); err != nil {
+		return nil, fmt.Errorf("cassette %s interaction %d: %w", r.path, r.next, err)
+	}
+
+	r.next++
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(string(interaction.Response.Body))),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Stop finishes the cassette. In ModeRecord it writes the recorded
+// interactions to the cassette file, so callers should skip it when the
+// recording went wrong to keep the previous file. In ModeReplay it fails if
+// some recorded interactions were never requested.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if unused := len(r.cassette.Interactions) - r.next; unused > 0 {
+			return fmt.Errorf("cassette %s has %d unused interactions, starting with %s %s",
+				r.path, unused, r.cassette.Interactions[r.next].Request.Method, r.cassette.Interactions[r.next].Request.Path)
+		}
+
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+// newRequest captures req, restoring its body so it can still be sent.
+func newRequest(req *http.Request) (Request, error) {
+	recorded := Request{Method: req.Method, Path: req.URL.RequestURI()}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = Body(body)
+
+	return recorded, nil
+}
+
+// match returns an error describing how got differs from the recorded
+// request. JSON bodies are compared by value, so formatting does not matter.
+func (want Request) match(got Request) error {
+	if got.Method != want.Method || got.Path != want.Path {
+		return fmt.Errorf("expected %s %s, got %s %s", want.Method, want.Path, got.Method, got.Path)
+	}
+
+	if !equalBodies(want.Body, got.Body) {
+		return fmt.Errorf("%s %s: expected body %s, got %s", got.Method, got.Path, want.Body, got.Body)
+	}
+
+	return nil
+}
+
+func equalBodies(want Body, got Body) bool {
+	if want == got {
+		return true
+	}
+
+	var wantValue, gotValue any
+	if json.Unmarshal([]byte(want), &wantValue) != nil || json.Unmarshal([]byte(got), &gotValue) != nil {
+		return false
+	}
+
+	// Marshaling sorts object keys, giving a canonical form to compare
+	wantJSON, err := json.Marshal(wantValue)
+	if err != nil {
+		return false
+	}
+
+	gotJSON, err := json.Marshal(gotValue)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(wantJSON, gotJSON)
+}
@@ -0,0 +1,137 @@
+package cassette
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	send := func(client *http.Client, body string) (int, string, error) {
+		res, err := client.Post(server.URL+"/engineers?x=1", "application/json", strings.NewReader(body))
+		if err != nil {
+			return 0, "", err
+		}
+		defer res.Body.Close()
+
+		resBody, err := io.ReadAll(res.Body)
+
+		return res.StatusCode, string(resBody), err
+	}
+
+	recorder, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := send(&http.Client{Transport: recorder}, `{"name":"Ryan","email":"ryan@example.com"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server is no longer needed to replay
+	server.Close()
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Key order and whitespace of JSON bodies do not matter
+	status, body, err := send(&http.Client{Transport: replayer}, `{"email": "ryan@example.com", "name": "Ryan"}`)
+	if err != nil {
+		t.Fatalf("unexpected replay error: %s", err)
+	}
+
+	var echo map[string]map[string]string
+	if status != http.StatusCreated || json.Unmarshal([]byte(body), &echo) != nil || echo["echo"]["name"] != "Ryan" {
+		t.Fatalf("expected the recorded response, got %d %s", status, body)
+	}
+
+	if err := replayer.Stop(); err != nil {
+		t.Fatalf("unexpected stop error: %s", err)
+	}
+}
+
+func TestRecorderReplayMismatch(t *testing.T) {
+	path := filepath.Join("testdata", "engineer.json")
+
+	cases := map[string]struct {
+		method  string
+		path    string
+		body    string
+		wantErr string
+	}{
+		"different body": {
+			method:  http.MethodPut,
+			path:    "/engineers/1",
+			body:    `{"name":"Ryan","email":"other@example.com"}`,
+			wantErr: "expected body",
+		},
+		"different path": {
+			method:  http.MethodPut,
+			path:    "/engineers/2",
+			body:    `{"name":"Ryan","email":"ryan@example.com"}`,
+			wantErr: "expected PUT /engineers/1, got PUT /engineers/2",
+		},
+		"different method": {
+			method:  http.MethodGet,
+			path:    "/engineers/1",
+			wantErr: "expected PUT /engineers/1, got GET /engineers/1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			replayer, err := New(path, ModeReplay, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, _ := http.NewRequest(tc.method, "http://bootcamp.invalid"+tc.path, strings.NewReader(tc.body))
+
+			_, err = replayer.RoundTrip(req)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+
+			if err := replayer.Stop(); err == nil || !strings.Contains(err.Error(), "1 unused interactions") {
+				t.Fatalf("expected an unused interaction error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBodyRoundTrip(t *testing.T) {
+	for _, body := range []Body{`{"id":"1"}`, `[1,2]`, `not json`, `"quoted"`, ``} {
+		data, err := json.Marshal(Response{Body: body})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Response
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		if !equalBodies(body, got.Body) {
+			t.Errorf("expected body %q, got %q from %s", body, got.Body, data)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import "net/http"
+
+// DefaultAPIKeyHeader is the header the API key is sent in unless the
+// provider configures api_key_header.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// AuthConfig holds the credentials DoRequest applies to every request. The
+// values are secrets and must never be logged.
+type AuthConfig struct {
+	Token        string
+	Username     string
+	Password     string
+	APIKey       string
+	APIKeyHeader string
+}
+
+// apply sets the configured credentials on req. A bearer token takes the
+// Authorization header, so it is never combined with basic auth.
+func (a AuthConfig) apply(req *http.Request) {
+	if a.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+	} else if a.Username != "" || a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+
+	if a.APIKey != "" {
+		header := a.APIKeyHeader
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		req.Header.Set(header, a.APIKey)
+	}
+}
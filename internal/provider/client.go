@@ -1,22 +1,92 @@
 package provider
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const HOST_URL = "http://localhost:8080"
 
+// DefaultRequestTimeout bounds a single HTTP request to the API unless the
+// provider configures request_timeout.
+const DefaultRequestTimeout = 60 * time.Second
+
+var (
+	// ErrNotFound is matched by errors.Is when the API responded with 404.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by errors.Is when the API responded with 409.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized is matched by errors.Is when the API responded with 401 or 403.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrServerError is matched by errors.Is when the API responded with a 5xx status.
+	ErrServerError = errors.New("server error")
+)
+
+// APIError is returned by DoRequest when the API responds with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Is reports whether the status code of the error belongs to the class of target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// AmbiguousError is returned by lookups that expect a single object but
+// matched several.
+type AmbiguousError struct {
+	Kind  string
+	Field string
+	Value string
+	Ids   []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%d %ss have %s %q: %s", len(e.Ids), e.Kind, e.Field, e.Value, strings.Join(e.Ids, ", "))
+}
+
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
+	Retry      RetryConfig
+	Auth       AuthConfig
+	// APIVersion is the version reported by the API, set by CheckHealth.
+	APIVersion string
+	// AllowedEmailDomains restricts the email of planned engineers when set.
+	AllowedEmailDomains []string
+	// WarnOnNameConflict downgrades planned duplicate names from an error to
+	// a warning.
+	WarnOnNameConflict bool
 }
 
 func NewClient(host *string) (*Client, error) {
 	c := Client{
-		HTTPClient: &http.Client{},
+		HTTPClient: &http.Client{Timeout: DefaultRequestTimeout},
 		HostURL:    HOST_URL,
+		Retry:      DefaultRetryConfig,
 	}
 
 	if host != nil {
@@ -26,23 +96,94 @@ func NewClient(host *string) (*Client, error) {
 	return &c, nil
 }
 
+// DoRequest sends req and returns the response body, retrying connection
+// errors, 429 and 5xx responses according to c.Retry.
 func (c *Client) DoRequest(req *http.Request) ([]byte, error) {
+	ctx := req.Context()
+
+	c.Auth.apply(req)
+
+	if req.Method == http.MethodPost && req.Header.Get(idempotencyKeyHeader) == "" {
+		req.Header.Set(idempotencyKeyHeader, newIdempotencyKey())
+	}
+
+	// net/http resends a request carrying an Idempotency-Key by itself when a
+	// reused connection fails, before canRetry could refuse it. Only requests
+	// without GetBody are safe from that, so the body is buffered here and
+	// rebuilt for every attempt. A POST always gets one, even if empty.
+	var reqBody []byte
+	hasBody := req.Body != nil || req.Method == http.MethodPost
+
+	if req.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if hasBody {
+			req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+			req.ContentLength = int64(len(reqBody))
+			req.GetBody = nil
+		}
+
+		body, res, err := c.doAttempt(req)
+
+		if err == nil {
+			return body, nil
+		}
+
+		if ctx.Err() != nil || attempt >= c.Retry.MaxRetries || !canRetry(req, res) {
+			return nil, err
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !isRetryableStatus(apiErr.StatusCode) {
+			return nil, err
+		}
+
+		wait := c.Retry.backoff(attempt)
+		if res != nil {
+			if retryAfter, ok := c.Retry.retryAfter(res); ok {
+				wait = retryAfter
+			}
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("retrying %s %s in %s after error: %s", req.Method, req.URL.Path, wait, err))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// doAttempt sends req once. The response is returned alongside an error so
+// its headers can be inspected when deciding whether to retry.
+func (c *Client) doAttempt(req *http.Request) ([]byte, *http.Response, error) {
 	res, err := c.HTTPClient.Do(req)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer res.Body.Close()
 
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	if res.StatusCode != 200 && res.StatusCode != 201 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, res, &APIError{StatusCode: res.StatusCode, Body: string(body)}
 	}
 
-	return body, err
+	return body, res, nil
 }
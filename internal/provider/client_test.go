@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-devops-bootcamp/internal/cassette"
+)
+
+// cassetteClient returns a Client replaying testdata/cassettes/<test>.json,
+// which fails any request that differs from the recording. With
+// DEVOPS_BOOTCAMP_RECORD set the cassette is recorded against testAccEndpoint
+// instead.
+//
+// The committed cassettes were recorded against the in-memory bootcampapi
+// fake, not the real bootcamp API: their ids and the "1.0.0" version are the
+// fake's. Replaying them shows the client agrees with the fake, re-record
+// with DEVOPS_BOOTCAMP_ENDPOINT set to check it against the real API.
+func cassetteClient(t *testing.T) *Client {
+	t.Helper()
+
+	mode := cassette.ModeReplay
+	url := "http://bootcamp.invalid"
+	if os.Getenv("DEVOPS_BOOTCAMP_RECORD") != "" {
+		mode = cassette.ModeRecord
+		url = testAccEndpoint
+	}
+
+	recorder, err := cassette.New(filepath.Join("testdata", "cassettes", t.Name()+".json"), mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		// Keep the previous recording rather than replace it with a partial one
+		if mode == cassette.ModeRecord && t.Failed() {
+			t.Logf("not writing the cassette of failed test %s", t.Name())
+			return
+		}
+
+		if err := recorder.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	client, _ := NewClient(&url)
+	client.Retry.MaxRetries = 0
+	client.HTTPClient.Transport = recorder
+
+	return client
+}
+
+// findById returns the object of list with id, or nil. The API may hold other
+// objects besides the ones a test created.
+func findById[T any](list []*T, id string, idOf func(*T) string) *T {
+	for _, object := range list {
+		if idOf(object) == id {
+			return object
+		}
+	}
+
+	return nil
+}
+
+func testRetryClient(url string) *Client {
+	client, _ := NewClient(&url)
+	client.Retry = RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   10 * time.Millisecond,
+	}
+
+	return client
+}
+
+func TestClientDoRequestRetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/engineers/id/1", nil)
+
+	body, err := client.DoRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(body) != `{"id":"1"}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClientDoRequestGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/dev", nil)
+
+	_, err := client.DoRequest(req)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts)
+	}
+}
+
+func TestClientDoRequestDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/engineers/id/missing", nil)
+
+	_, err := client.DoRequest(req)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClientDoRequestRetriesPostOnlyWhenIdempotencyKeyAcknowledged(t *testing.T) {
+	for name, acknowledge := range map[string]bool{"acknowledged": true, "unacknowledged": false} {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if acknowledge {
+					w.Header().Set(idempotencyKeyHeader, r.Header.Get(idempotencyKeyHeader))
+				}
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			defer server.Close()
+
+			client := testRetryClient(server.URL)
+			client.Retry.MaxRetries = 1
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/engineers", nil)
+
+			_, err := client.DoRequest(req)
+			if !errors.Is(err, ErrServerError) {
+				t.Fatalf("expected ErrServerError, got %v", err)
+			}
+
+			expected := 1
+			if acknowledge {
+				expected = 2
+			}
+			if attempts != expected {
+				t.Errorf("expected %d attempts, got %d", expected, attempts)
+			}
+		})
+	}
+}
+
+func TestClientDoRequestDoesNotReplayResetPost(t *testing.T) {
+	// The client sees the reset while the handler is still running
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		if posts.Add(1) > 1 {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+			return
+		}
+
+		// Drop the connection without a response nor an acknowledged key
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+
+	// Leave an idle connection for the POST to reuse
+	if _, err := client.ListEngineers(context.Background()); err != nil {
+		t.Fatalf("ListEngineers: %s", err)
+	}
+
+	_, err := client.CreateEngineer(context.Background(), "Bobby", "bobby@bobby.com")
+	if err == nil {
+		t.Fatal("expected an error from the reset POST")
+	}
+	if sent := posts.Load(); sent != 1 {
+		t.Errorf("expected the POST to be sent once, got %d", sent)
+	}
+}
+
+func TestClientHonorsContextCancellation(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+	client.Retry.BaseDelay = time.Minute
+	client.Retry.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetEngineer(ctx, "1")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request was not aborted by the context, took %s", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClientAppliesAuthentication(t *testing.T) {
+	cases := map[string]struct {
+		auth   AuthConfig
+		header string
+		want   string
+	}{
+		"bearer token": {
+			auth:   AuthConfig{Token: "secret"},
+			header: "Authorization",
+			want:   "Bearer secret",
+		},
+		"basic auth": {
+			auth:   AuthConfig{Username: "bobby", Password: "hunter2"},
+			header: "Authorization",
+			want:   "Basic Ym9iYnk6aHVudGVyMg==",
+		},
+		"default api key header": {
+			auth:   AuthConfig{APIKey: "key"},
+			header: DefaultAPIKeyHeader,
+			want:   "key",
+		},
+		"custom api key header": {
+			auth:   AuthConfig{APIKey: "key", APIKeyHeader: "X-Bootcamp-Key"},
+			header: "X-Bootcamp-Key",
+			want:   "key",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(tc.header)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := testRetryClient(server.URL)
+			client.Auth = tc.auth
+
+			if _, err := client.GetEngineer(context.Background(), "1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %s header %q, got %q", tc.header, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestClientFindEngineerByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id":"1","name":"Ryan","email":"ryan@example.com"},
+			{"id":"2","name":"Ryan","email":"ryan2@example.com"},
+			{"id":"3","name":"Ava","email":"Ava@example.com"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+
+	engineer, err := client.FindEngineerByEmail(context.Background(), "ava@example.com")
+	if err != nil || engineer.Id != "3" {
+		t.Errorf("expected engineer 3, got %v, %v", engineer, err)
+	}
+
+	_, err = client.FindEngineerByName(context.Background(), "Ryan")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Ids) != 2 {
+		t.Errorf("expected an AmbiguousError with 2 ids, got %v", err)
+	}
+
+	_, err = client.FindEngineerByName(context.Background(), "Bobby")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
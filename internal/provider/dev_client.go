@@ -2,6 +2,7 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,14 +11,14 @@ import (
 )
 
 // Function to create a dev
-func (c *Client) CreateDev(dev *devops_resource.Dev) (*devops_resource.Dev, error) {
+func (c *Client) CreateDev(ctx context.Context, dev *devops_resource.Dev) (*devops_resource.Dev, error) {
 	reqBody, err := json.Marshal(dev)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/dev", c.HostURL), bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/dev", c.HostURL), bytes.NewBuffer(reqBody))
 
 	if err != nil {
 		return nil, err
@@ -40,8 +41,8 @@ func (c *Client) CreateDev(dev *devops_resource.Dev) (*devops_resource.Dev, erro
 	return &newDev, nil
 }
 
-func (c *Client) GetDevByName(name string) (*devops_resource.Dev, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev/name/%s", c.HostURL, name), nil)
+func (c *Client) GetDevByName(ctx context.Context, name string) (*devops_resource.Dev, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/dev/name/%s", c.HostURL, name), nil)
 
 	if err != nil {
 		return nil, err
@@ -64,8 +65,8 @@ func (c *Client) GetDevByName(name string) (*devops_resource.Dev, error) {
 	return &dev, nil
 }
 
-func (c *Client) GetDevById(id string) (*devops_resource.Dev, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev/id/%s", c.HostURL, id), nil)
+func (c *Client) GetDevById(ctx context.Context, id string) (*devops_resource.Dev, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/dev/id/%s", c.HostURL, id), nil)
 
 	if err != nil {
 		return nil, err
@@ -88,8 +89,8 @@ func (c *Client) GetDevById(id string) (*devops_resource.Dev, error) {
 	return &dev, nil
 }
 
-func (c *Client) DeleteDev(dev *devops_resource.Dev) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/dev/%s", c.HostURL, dev.Id), nil)
+func (c *Client) DeleteDev(ctx context.Context, dev *devops_resource.Dev) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/dev/%s", c.HostURL, dev.Id), nil)
 
 	if err != nil {
 		return err
@@ -100,14 +101,14 @@ func (c *Client) DeleteDev(dev *devops_resource.Dev) error {
 	return err
 }
 
-func (c *Client) UpdateDev(dev *devops_resource.Dev) (*devops_resource.Dev, error) {
+func (c *Client) UpdateDev(ctx context.Context, dev *devops_resource.Dev) (*devops_resource.Dev, error) {
 	reqBody, err := json.Marshal(dev)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/dev/%s", c.HostURL, dev.Id), bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/dev/%s", c.HostURL, dev.Id), bytes.NewBuffer(reqBody))
 
 	if err != nil {
 		return nil, err
@@ -129,3 +130,58 @@ func (c *Client) UpdateDev(dev *devops_resource.Dev) (*devops_resource.Dev, erro
 
 	return &newDev, nil
 }
+
+func (c *Client) ListDevs(ctx context.Context) ([]*devops_resource.Dev, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/dev", c.HostURL), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	devs := []*devops_resource.Dev{}
+
+	err = json.Unmarshal(body, &devs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return devs, nil
+}
+
+// FindDevByName returns the only dev named name. Unlike GetDevByName it
+// detects duplicate names, returning an *AmbiguousError.
+func (c *Client) FindDevByName(ctx context.Context, name string) (*devops_resource.Dev, error) {
+	devs, err := c.ListDevs(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*devops_resource.Dev
+	for _, dev := range devs {
+		if dev.Name == name {
+			found = append(found, dev)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no dev has name %q: %w", name, ErrNotFound)
+	case 1:
+		return found[0], nil
+	}
+
+	ambiguous := &AmbiguousError{Kind: "dev", Field: "name", Value: name}
+	for _, dev := range found {
+		ambiguous.Ids = append(ambiguous.Ids, dev.Id)
+	}
+
+	return nil, ambiguous
+}
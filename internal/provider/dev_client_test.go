@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestDevClient(t *testing.T) {
+	client := cassetteClient(t)
+	ctx := context.Background()
+
+	engineer, err := client.CreateEngineer(ctx, "tf-acc-CassetteDevEngineer", "dev@cassette.com")
+	if err != nil {
+		t.Fatalf("CreateEngineer: %s", err)
+	}
+
+	created, err := client.CreateDev(ctx, &devops_resource.Dev{
+		Name:      "tf-acc-CassetteDevs",
+		Engineers: []*devops_resource.Engineer{{Id: engineer.Id}},
+	})
+	if err != nil {
+		t.Fatalf("CreateDev: %s", err)
+	}
+	if created.Id == "" || created.Name != "tf-acc-CassetteDevs" || len(created.Engineers) != 1 || *created.Engineers[0] != *engineer {
+		t.Fatalf("CreateDev: unexpected dev %+v", created)
+	}
+
+	dev, err := client.GetDevById(ctx, created.Id)
+	if err != nil || dev.Name != "tf-acc-CassetteDevs" || len(dev.Engineers) != 1 {
+		t.Fatalf("GetDevById: unexpected dev %+v, %v", dev, err)
+	}
+
+	dev, err = client.GetDevByName(ctx, "tf-acc-CassetteDevs")
+	if err != nil || dev.Id != created.Id {
+		t.Fatalf("GetDevByName: expected %s, got %+v, %v", created.Id, dev, err)
+	}
+
+	updated, err := client.UpdateDev(ctx, &devops_resource.Dev{
+		Id:        created.Id,
+		Name:      "tf-acc-RenamedCassetteDevs",
+		Engineers: []*devops_resource.Engineer{},
+	})
+	if err != nil || updated.Name != "tf-acc-RenamedCassetteDevs" || len(updated.Engineers) != 0 {
+		t.Fatalf("UpdateDev: unexpected dev %+v, %v", updated, err)
+	}
+
+	devs, err := client.ListDevs(ctx)
+	if err != nil || findById(devs, created.Id, func(dev *devops_resource.Dev) string { return dev.Id }) == nil {
+		t.Fatalf("ListDevs: expected %s among %v, %v", created.Id, devs, err)
+	}
+
+	dev, err = client.FindDevByName(ctx, "tf-acc-RenamedCassetteDevs")
+	if err != nil || dev.Id != created.Id {
+		t.Fatalf("FindDevByName: expected %s, got %+v, %v", created.Id, dev, err)
+	}
+
+	if err := client.DeleteDev(ctx, created); err != nil {
+		t.Fatalf("DeleteDev: %s", err)
+	}
+
+	_, err = client.GetDevById(ctx, created.Id)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetDevById: expected ErrNotFound after delete, got %v", err)
+	}
+
+	if err := client.DeleteEngineer(ctx, engineer); err != nil {
+		t.Fatalf("DeleteEngineer: %s", err)
+	}
+}
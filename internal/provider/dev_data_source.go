@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -29,10 +30,12 @@ type DevDataSource struct {
 }
 
 type DevModel struct {
-	Id          types.String    `tfsdk:"id"`
-	Name        types.String    `tfsdk:"name"`
-	Engineers   []EngineerModel `tfsdk:"engineers"`
-	LastUpdated types.String    `tfsdk:"last_updated"`
+	Id             types.String    `tfsdk:"id"`
+	Name           types.String    `tfsdk:"name"`
+	Engineers      []EngineerModel `tfsdk:"engineers"`
+	LastUpdated    types.String    `tfsdk:"last_updated"`
+	FailIfNotFound types.Bool      `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool      `tfsdk:"exists"`
 }
 
 func (d *DevDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -76,6 +79,14 @@ func (d *DevDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"fail_if_not_found": schema.BoolAttribute{
+				MarkdownDescription: "Fail when no developer group has the name. When `false` a missing group sets `exists` to `false` and leaves the other attributes null. Defaults to `true`.",
+				Optional:            true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether a developer group with the name exists",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -101,6 +112,10 @@ func (d *DevDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 }
 
 func (d *DevDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !clientConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state DevModel
 
 	// Read Terraform configuration data into the model
@@ -111,7 +126,15 @@ func (d *DevDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 	}
 
 	// Fetch the existing dev from the API
-	dev, err := d.client.GetDevByName(state.Name.ValueString())
+	dev, err := d.client.GetDevByName(ctx, state.Name.ValueString())
+	if errors.Is(err, ErrNotFound) && !state.FailIfNotFound.IsNull() && !state.FailIfNotFound.ValueBool() {
+		tflog.Debug(ctx, fmt.Sprintf("dev %s not found, ignoring", state.Name))
+		state.Exists = types.BoolValue(false)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
 		return
@@ -119,6 +142,7 @@ func (d *DevDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	state.Id = types.StringValue(dev.Id)
 	state.Name = types.StringValue(dev.Name)
+	state.Exists = types.BoolValue(true)
 
 	state.Engineers = []EngineerModel{}
 	for _, engineer := range dev.Engineers {
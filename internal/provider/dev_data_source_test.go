@@ -16,20 +16,34 @@ func TestAccDevDataSource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccDevDataSourceConfig,
+				Config: providerConfig + testAccDevDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.devops-bootcamp_dev.test", "name", "Ryan"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_dev.test", "name", "tf-acc-Ryan"),
 					resource.TestCheckResourceAttrSet("data.devops-bootcamp_dev.test", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_dev.test", "exists", "true"),
+				),
+			},
+			// Missing dev without failing
+			{
+				Config: providerConfig + `
+	data "devops-bootcamp_dev" "missing" {
+	  name              = "NobodyByThisName"
+	  fail_if_not_found = false
+	}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_dev.missing", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.devops-bootcamp_dev.missing", "id"),
 				),
 			},
 		},
 	})
 }
 
-const testAccDevDataSourceConfig = providerConfig + `
+const testAccDevDataSourceConfig = `
 
 	resource "devops-bootcamp_dev" "test" {
-		name  = "Ryan"
+		name  = "tf-acc-Ryan"
 		engineers = []
 	}
 
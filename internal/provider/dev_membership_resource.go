@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DevMembershipResource{}
+var _ resource.ResourceWithImportState = &DevMembershipResource{}
+
+// devMembershipLocks serializes the read-modify-write of a dev group's
+// engineers, so memberships of the same group applied in parallel do not
+// overwrite each other.
+var devMembershipLocks sync.Map
+
+func lockDev(devId string) func() {
+	lock, _ := devMembershipLocks.LoadOrStore(devId, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex) //nolint:forcetypeassert // only *sync.Mutex is stored
+
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+func NewDevMembershipResource() resource.Resource {
+	return &DevMembershipResource{}
+}
+
+// DevMembershipResource defines the resource implementation.
+type DevMembershipResource struct {
+	client *Client
+}
+
+// DevMembershipResourceModel describes the resource data model.
+type DevMembershipResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	DevId      types.String `tfsdk:"dev_id"`
+	EngineerId types.String `tfsdk:"engineer_id"`
+}
+
+func (r *DevMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dev_membership"
+}
+
+func (r *DevMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Membership of a single engineer in a developer group. Unlike the `engineers` of `devops-bootcamp_dev` " +
+			"it is non-authoritative, other members of the group are left alone. Do not combine both for the same group.",
+
+		Attributes: map[string]schema.Attribute{
+			"dev_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the developer group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"engineer_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the engineer",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Membership identifier in the form `dev_id/engineer_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DevMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DevMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan *DevMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devId := plan.DevId.ValueString()
+	engineerId := plan.EngineerId.ValueString()
+
+	unlock := lockDev(devId)
+	defer unlock()
+
+	dev, err := r.client.GetDevById(ctx, devId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dev %s, got error: %s", devId, err))
+		return
+	}
+
+	// Only add the engineer if it is not already a member
+	if !engineerIds(dev.Engineers)[engineerId] {
+		dev.Engineers = append(dev.Engineers, &devops_resource.Engineer{Id: engineerId})
+
+		_, err = r.client.UpdateDev(ctx, dev)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating dev membership",
+				"Could not add engineer to dev,unexpected error:"+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.Id = types.StringValue(devMembershipId(devId, engineerId))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a dev membership resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DevMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !clientRefreshable(ctx, r.client) {
+		return
+	}
+
+	var state *DevMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dev, err := r.client.GetDevById(ctx, state.DevId.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// The dev was deleted outside of Terraform, so the membership is gone too
+			tflog.Warn(ctx, fmt.Sprintf("dev %s not found, removing membership from state", state.DevId))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dev membership, got error: %s", err))
+		return
+	}
+
+	if !engineerIds(dev.Engineers)[state.EngineerId.ValueString()] {
+		tflog.Warn(ctx, fmt.Sprintf("engineer %s is no longer a member of dev %s, removing from state", state.EngineerId, state.DevId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(devMembershipId(state.DevId.ValueString(), state.EngineerId.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DevMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Both attributes require replacement, so there is nothing to update in place
+	var plan *DevMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DevMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state *DevMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devId := state.DevId.ValueString()
+	engineerId := state.EngineerId.ValueString()
+
+	unlock := lockDev(devId)
+	defer unlock()
+
+	dev, err := r.client.GetDevById(ctx, devId)
+
+	// Nothing left to delete if the dev is already gone
+	if errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dev %s, got error: %s", devId, err))
+		return
+	}
+
+	engineers := make([]*devops_resource.Engineer, 0, len(dev.Engineers))
+	for _, engineer := range dev.Engineers {
+		if engineer.Id != engineerId {
+			engineers = append(engineers, engineer)
+		}
+	}
+
+	if len(engineers) == len(dev.Engineers) {
+		return
+	}
+
+	dev.Engineers = engineers
+
+	_, err = r.client.UpdateDev(ctx, dev)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Dev Membership",
+			"Could not remove engineer from dev, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *DevMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	devId, engineerId, ok := strings.Cut(req.ID, "/")
+
+	if !ok || devId == "" || engineerId == "" || strings.Contains(engineerId, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier in the form dev_id/engineer_id, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dev_id"), devId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("engineer_id"), engineerId)...)
+}
+
+// devMembershipId returns the composite id of a dev membership.
+func devMembershipId(devId string, engineerId string) string {
+	return devId + "/" + engineerId
+}
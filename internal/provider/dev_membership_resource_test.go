@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestDevMembershipResource(t *testing.T) {
+	var devId, engineerId string
+
+	config := providerConfig + testAccDevMembershipBaseConfig + `
+resource "devops-bootcamp_dev_membership" "test" {
+	dev_id      = devops-bootcamp_dev.test.id
+	engineer_id = devops-bootcamp_engineer.member.id
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("devops-bootcamp_dev_membership.test", "dev_id", "devops-bootcamp_dev.test", "id"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_dev_membership.test", "engineer_id", "devops-bootcamp_engineer.member", "id"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_dev_membership.test", "id"),
+					testAccCaptureId("devops-bootcamp_dev.test", &devId),
+					testAccCaptureId("devops-bootcamp_engineer.member", &engineerId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devops-bootcamp_dev_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Removing the engineer from the dev outside of Terraform is detected
+			// and the membership recreated
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					dev, err := client.GetDevById(ctx, devId)
+					if err != nil {
+						return err
+					}
+					dev.Engineers = []*devops_resource.Engineer{}
+					_, err = client.UpdateDev(ctx, dev)
+					return err
+				}),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_dev_membership.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				Config: config,
+				Check: testAccCheckAPI(func(ctx context.Context, client *Client) error {
+					dev, err := client.GetDevById(ctx, devId)
+					if err != nil {
+						return err
+					}
+					if !engineerIds(dev.Engineers)[engineerId] {
+						return fmt.Errorf("expected engineer %s to be a member of dev %s again", engineerId, devId)
+					}
+					return nil
+				}),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// The dev group's own engineers are ignored so the membership managed
+// alongside it does not produce a diff on the group.
+const testAccDevMembershipBaseConfig = `
+resource "devops-bootcamp_engineer" "member" {
+	name  = "tf-acc-BobbyMember"
+	email = "bobbyMember@bobby.com"
+}
+resource "devops-bootcamp_dev" "test" {
+	name      = "tf-acc-BobbyMembership"
+	engineers = []
+
+	lifecycle {
+		ignore_changes = [engineers]
+	}
+}
+`
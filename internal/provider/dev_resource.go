@@ -5,14 +5,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
@@ -21,6 +28,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DevResource{}
 var _ resource.ResourceWithImportState = &DevResource{}
+var _ resource.ResourceWithUpgradeState = &DevResource{}
+var _ resource.ResourceWithModifyPlan = &DevResource{}
+var _ resource.ResourceWithValidateConfig = &DevResource{}
 
 func NewDevResource() resource.Resource {
 	return &DevResource{}
@@ -36,7 +46,18 @@ type DevResourceModel struct {
 	Id          types.String    `tfsdk:"id"`
 	Name        types.String    `tfsdk:"name"`
 	Engineers   []EngineerModel `tfsdk:"engineers"`
+	EngineerIds types.List      `tfsdk:"engineer_ids"`
 	LastUpdated types.String    `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value  `tfsdk:"timeouts"`
+}
+
+// devResourceModelV0 describes the version 0 state, before engineer_ids.
+type devResourceModelV0 struct {
+	Id          types.String    `tfsdk:"id"`
+	Name        types.String    `tfsdk:"name"`
+	Engineers   []EngineerModel `tfsdk:"engineers"`
+	LastUpdated types.String    `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value  `tfsdk:"timeouts"`
 }
 
 func (r *DevResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,18 +69,27 @@ func (r *DevResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Developer group resource",
 
+		// Version 1 stores engineers as a set instead of a list
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the developer group",
 				Required:            true,
 			},
-			"engineers": schema.ListNestedAttribute{
-				MarkdownDescription: "List of engineers in the developer group by id",
-				Required:            true,
+			"engineers": schema.SetNestedAttribute{
+				MarkdownDescription: "Set of engineers in the developer group by id. Exactly one of `engineers` and `engineer_ids` must be set, " +
+					"when `engineer_ids` is set the engineers are computed from it. The name and email of members are planned from the API, " +
+					"an engineer renamed in the same apply shows its new details after the next refresh.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Set{
+					setvalidator.ExactlyOneOf(path.MatchRoot("engineer_ids")),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
-							Optional: true,
+							Required: true,
 						},
 						"name": schema.StringAttribute{
 							Computed: true,
@@ -73,6 +103,13 @@ func (r *DevResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					},
 				},
 			},
+			"engineer_ids": schema.ListAttribute{
+				MarkdownDescription: "Ids of the engineers in the developer group. Unlike `engineers` an id listed twice is reported instead of merged. " +
+					"When `engineers` is set, or after an import, the ids are computed from the members, keeping the order of the previous ids.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Dev identifier",
@@ -84,7 +121,409 @@ func (r *DevResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DevResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored engineers as a list, the elements carry over unchanged
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"engineers": schema.ListNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Optional: true,
+								},
+								"name": schema.StringAttribute{
+									Computed: true,
+								},
+								"email": schema.StringAttribute{
+									Computed: true,
+								},
+								"last_updated": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+					},
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"last_updated": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior devResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// A set cannot hold the same engineer twice
+				seen := map[string]bool{}
+				engineers := []EngineerModel{}
+				var ids []string
+				for _, engineer := range prior.Engineers {
+					if seen[engineer.Id.ValueString()] {
+						continue
+					}
+					seen[engineer.Id.ValueString()] = true
+					engineers = append(engineers, engineer)
+					ids = append(ids, engineer.Id.ValueString())
+				}
+
+				upgraded := DevResourceModel{
+					Id:          prior.Id,
+					Name:        prior.Name,
+					Engineers:   engineers,
+					EngineerIds: engineerIdsValue(ids, types.ListNull(types.StringType)),
+					LastUpdated: prior.LastUpdated,
+					Timeouts:    prior.Timeouts,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
+func (r *DevResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var engineerIds types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineer_ids"), &engineerIds)...)
+
+	if resp.Diagnostics.HasError() || engineerIds.IsNull() || engineerIds.IsUnknown() {
+		return
+	}
+
+	// The engineers set merges repeated ids before validation, only the list
+	// can show that an id was listed more than once
+	seen := map[string]bool{}
+	for i, element := range engineerIds.Elements() {
+		id, ok := element.(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+
+		if seen[id.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineer_ids").AtListIndex(i),
+				"Duplicate Engineer",
+				fmt.Sprintf("The engineer %s is listed more than once in the developer group.", id.ValueString()),
+			)
+		}
+		seen[id.ValueString()] = true
+	}
+}
+
+func (r *DevResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var name types.String
+	var engineers types.Set
+	var engineerIds types.List
+	var state *DevResourceModel
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+	// The planned engineer_ids holds the computed ids when not configured
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineer_ids"), &engineerIds)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkNameConflict(ctx, name, state, &resp.Diagnostics)
+
+	if !engineerIds.IsNull() {
+		var d diag.Diagnostics
+		engineers, d = engineersFromIds(engineerIds)
+		resp.Diagnostics.Append(d...)
+	}
+
+	engineers = r.planEngineers(ctx, engineers, state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if engineerIds.IsNull() {
+		priorIds := types.ListNull(types.StringType)
+		if state != nil {
+			priorIds = state.EngineerIds
+		}
+		engineerIds = engineerIdsFromSet(engineers, priorIds)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("engineers"), engineers)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("engineer_ids"), engineerIds)...)
+}
+
+// checkNameConflict reports a planned name that is already used by a dev
+// group other than the one in state.
+func (r *DevResource) checkNameConflict(ctx context.Context, name types.String, state *DevResourceModel, diags *diag.Diagnostics) {
+	// Only creates and renames can introduce a duplicate name
+	if name.IsNull() || name.IsUnknown() || (state != nil && state.Name.Equal(name)) {
+		return
+	}
+
+	devs, err := r.client.ListDevs(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to check dev name uniqueness, got error: %s", err))
+		return
 	}
+
+	var ids []string
+	for _, dev := range devs {
+		if dev.Name == name.ValueString() && (state == nil || dev.Id != state.Id.ValueString()) {
+			ids = append(ids, dev.Id)
+		}
+	}
+
+	addNameConflict(diags, r.client.WarnOnNameConflict, "dev", name.ValueString(), ids)
+}
+
+// planEngineers fills in the name and email of the planned engineers that
+// are already in the group from their just refreshed state values, so an
+// unchanged group plans no difference. New ids are resolved through the API,
+// which also reports ids that do not exist. Ids that are unknown until apply
+// are left as planned.
+func (r *DevResource) planEngineers(ctx context.Context, engineers types.Set, state *DevResourceModel, diags *diag.Diagnostics) types.Set {
+	if engineers.IsNull() || engineers.IsUnknown() {
+		return engineers
+	}
+
+	members := map[string]EngineerModel{}
+	if state != nil {
+		for _, engineer := range state.Engineers {
+			members[engineer.Id.ValueString()] = engineer
+		}
+	}
+
+	elements := make([]attr.Value, 0, len(engineers.Elements()))
+	for _, element := range engineers.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			elements = append(elements, element)
+			continue
+		}
+
+		id, ok := object.Attributes()["id"].(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			elements = append(elements, element)
+			continue
+		}
+
+		planned := EngineerModel{
+			Id:          id,
+			LastUpdated: types.StringNull(),
+		}
+
+		if member, ok := members[id.ValueString()]; ok {
+			planned.Name = member.Name
+			planned.Email = member.Email
+		} else {
+			idPath := path.Root("engineers").AtSetValue(element).AtName("id")
+
+			engineer, err := r.client.GetEngineer(ctx, id.ValueString())
+
+			switch {
+			case errors.Is(err, ErrNotFound):
+				diags.AddAttributeError(
+					idPath,
+					"Engineer Not Found",
+					fmt.Sprintf("No engineer with id %s exists. Create the engineer before adding it to the developer group.", id.ValueString()),
+				)
+				continue
+			case err != nil:
+				diags.AddAttributeError(idPath, "Client Error", fmt.Sprintf("Unable to read engineer %s, got error: %s", id.ValueString(), err))
+				continue
+			}
+
+			planned.Name = types.StringValue(engineer.Name)
+			planned.Email = types.StringValue(engineer.Email)
+		}
+
+		value, d := types.ObjectValueFrom(ctx, engineerAttrTypes, planned)
+		diags.Append(d...)
+		elements = append(elements, value)
+	}
+
+	planned, d := types.SetValue(types.ObjectType{AttrTypes: engineerAttrTypes}, elements)
+	diags.Append(d...)
+
+	return planned
+}
+
+// engineersFromIds returns the engineers set for the ids in engineer_ids,
+// with only the ids known. planEngineers fills in the rest.
+func engineersFromIds(engineerIds types.List) (types.Set, diag.Diagnostics) {
+	objectType := types.ObjectType{AttrTypes: engineerAttrTypes}
+
+	if engineerIds.IsUnknown() {
+		return types.SetUnknown(objectType), nil
+	}
+
+	var diags diag.Diagnostics
+
+	elements := make([]attr.Value, 0, len(engineerIds.Elements()))
+	for _, id := range engineerIds.Elements() {
+		element, d := types.ObjectValue(engineerAttrTypes, map[string]attr.Value{
+			"id":           id,
+			"name":         types.StringUnknown(),
+			"email":        types.StringUnknown(),
+			"last_updated": types.StringUnknown(),
+		})
+		diags.Append(d...)
+		elements = append(elements, element)
+	}
+
+	engineers, d := types.SetValue(objectType, elements)
+	diags.Append(d...)
+
+	return engineers, diags
+}
+
+// appliedEngineers returns the members of an applied dev group. Members keep
+// the details they were planned with, as Terraform rejects applied values
+// that differ from known planned ones. An engineer renamed in the same apply
+// thus shows its new details from the next refresh on.
+func appliedEngineers(engineers []*devops_resource.Engineer, planned []EngineerModel) []EngineerModel {
+	plannedById := map[string]EngineerModel{}
+	for _, engineer := range planned {
+		plannedById[engineer.Id.ValueString()] = engineer
+	}
+
+	models := []EngineerModel{}
+	for _, engineer := range engineers {
+		model := EngineerModel{
+			Id:    types.StringValue(engineer.Id),
+			Name:  types.StringValue(engineer.Name),
+			Email: types.StringValue(engineer.Email),
+		}
+
+		if member, ok := plannedById[engineer.Id]; ok {
+			if !member.Name.IsNull() && !member.Name.IsUnknown() {
+				model.Name = member.Name
+			}
+			if !member.Email.IsNull() && !member.Email.IsUnknown() {
+				model.Email = member.Email
+			}
+		}
+
+		models = append(models, model)
+	}
+
+	return models
+}
+
+// engineerIdsFromSet returns the engineer_ids of the engineers set, unknown
+// while any id is.
+func engineerIdsFromSet(engineers types.Set, prior types.List) types.List {
+	if engineers.IsNull() {
+		return types.ListNull(types.StringType)
+	}
+	if engineers.IsUnknown() {
+		return types.ListUnknown(types.StringType)
+	}
+
+	var ids []string
+	for _, element := range engineers.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			return types.ListUnknown(types.StringType)
+		}
+
+		id, ok := object.Attributes()["id"].(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			return types.ListUnknown(types.StringType)
+		}
+
+		ids = append(ids, id.ValueString())
+	}
+
+	return engineerIdsValue(ids, prior)
+}
+
+// engineerIdsValue returns ids as an engineer_ids value. Ids in prior keep
+// its order, so refreshing a configured list plans no difference, and the
+// others follow sorted so the order does not depend on the API.
+func engineerIdsValue(ids []string, prior types.List) types.List {
+	positions := map[string]int{}
+	for i, element := range prior.Elements() {
+		id, ok := element.(types.String)
+		if !ok {
+			continue
+		}
+		if _, ok := positions[id.ValueString()]; !ok {
+			positions[id.ValueString()] = i
+		}
+	}
+
+	ordered := append([]string{}, ids...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, iok := positions[ordered[i]]
+		pj, jok := positions[ordered[j]]
+
+		switch {
+		case iok && jok:
+			return pi < pj
+		case iok != jok:
+			return iok
+		}
+
+		return ordered[i] < ordered[j]
+	})
+
+	elements := make([]attr.Value, 0, len(ordered))
+	for _, id := range ordered {
+		elements = append(elements, types.StringValue(id))
+	}
+
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// devEngineerIds returns the ids of the members of dev.
+func devEngineerIds(dev *devops_resource.Dev) []string {
+	ids := []string{}
+	for _, engineer := range dev.Engineers {
+		ids = append(ids, engineer.Id)
+	}
+
+	return ids
 }
 
 func (r *DevResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -108,6 +547,10 @@ func (r *DevResource) Configure(ctx context.Context, req resource.ConfigureReque
 }
 
 func (r *DevResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var planned *DevResourceModel
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
@@ -116,6 +559,17 @@ func (r *DevResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := planned.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	start := time.Now()
+
 	// Generate API request body from plan
 	var reqObj devops_resource.Dev
 	reqObj.Name = planned.Name.ValueString()
@@ -132,9 +586,13 @@ func (r *DevResource) Create(ctx context.Context, req resource.CreateRequest, re
 		reqObj.Engineers = make([]*devops_resource.Engineer, 0)
 	}
 
-	dev, err := r.client.CreateDev(&reqObj)
+	dev, err := r.client.CreateDev(ctx, &reqObj)
 
 	if err != nil {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "creating dev", createTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error creating dev",
 			"Could not create dev,unexpected error:"+err.Error(),
@@ -146,15 +604,8 @@ func (r *DevResource) Create(ctx context.Context, req resource.CreateRequest, re
 	planned.Id = types.StringValue(dev.Id)
 	planned.Name = types.StringValue(dev.Name)
 	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-	planned.Engineers = []EngineerModel{}
-
-	for _, engineer := range dev.Engineers {
-		planned.Engineers = append(planned.Engineers, EngineerModel{
-			Id:    types.StringValue(engineer.Id),
-			Name:  types.StringValue(engineer.Name),
-			Email: types.StringValue(engineer.Email),
-		})
-	}
+	planned.Engineers = appliedEngineers(dev.Engineers, planned.Engineers)
+	planned.EngineerIds = engineerIdsValue(devEngineerIds(dev), planned.EngineerIds)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -165,6 +616,10 @@ func (r *DevResource) Create(ctx context.Context, req resource.CreateRequest, re
 }
 
 func (r *DevResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !clientRefreshable(ctx, r.client) {
+		return
+	}
+
 	var state *DevResourceModel
 
 	// Read Terraform prior state data into the model
@@ -174,8 +629,30 @@ func (r *DevResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	dev, err := r.client.GetDevById(state.Id.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	start := time.Now()
+
+	dev, err := r.client.GetDevById(ctx, state.Id.ValueString())
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// The dev was deleted outside of Terraform, remove it from state so it is recreated
+			tflog.Warn(ctx, fmt.Sprintf("dev %s not found, removing from state", state.Id))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		if timeoutExceeded(ctx, &resp.Diagnostics, "reading dev", readTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
 		return
 	}
@@ -191,6 +668,7 @@ func (r *DevResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			Email: types.StringValue(engineer.Email),
 		})
 	}
+	state.EngineerIds = engineerIdsValue(devEngineerIds(dev), state.EngineerIds)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -201,6 +679,10 @@ func (r *DevResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *DevResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var planned *DevResourceModel
 
 	// Read Terraform plan data into the model
@@ -210,21 +692,35 @@ func (r *DevResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	updateTimeout, diags := planned.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	start := time.Now()
+
 	// Generate API request body from plan
 	var reqObj devops_resource.Dev
 	reqObj.Id = planned.Id.ValueString()
 	reqObj.Name = planned.Name.ValueString()
 	for _, engineer := range planned.Engineers {
+		// Members are referenced by id, the API fills in their details
 		reqObj.Engineers = append(reqObj.Engineers, &devops_resource.Engineer{
-			Id:    engineer.Id.ValueString(),
-			Name:  engineer.Name.ValueString(),
-			Email: engineer.Email.ValueString(),
+			Id: engineer.Id.ValueString(),
 		})
 	}
 
 	// update the dev
-	_, err := r.client.UpdateDev(&reqObj)
+	_, err := r.client.UpdateDev(ctx, &reqObj)
 	if err != nil {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "updating dev", updateTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error updating dev",
 			"Could not update dev,unexpected error:"+err.Error(),
@@ -233,8 +729,12 @@ func (r *DevResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	// Fetch the updated dev from the API
-	dev, err := r.client.GetDevById(planned.Id.ValueString())
+	dev, err := r.client.GetDevById(ctx, planned.Id.ValueString())
 	if err != nil {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "updating dev", updateTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
 		return
 	}
@@ -243,14 +743,8 @@ func (r *DevResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	planned.Id = types.StringValue(dev.Id)
 	planned.Name = types.StringValue(dev.Name)
 	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-	planned.Engineers = []EngineerModel{}
-	for _, engineer := range dev.Engineers {
-		planned.Engineers = append(planned.Engineers, EngineerModel{
-			Id:    types.StringValue(engineer.Id),
-			Name:  types.StringValue(engineer.Name),
-			Email: types.StringValue(engineer.Email),
-		})
-	}
+	planned.Engineers = appliedEngineers(dev.Engineers, planned.Engineers)
+	planned.EngineerIds = engineerIdsValue(devEngineerIds(dev), planned.EngineerIds)
 
 	tflog.Trace(ctx, "updated a dev resource")
 
@@ -259,6 +753,10 @@ func (r *DevResource) Update(ctx context.Context, req resource.UpdateRequest, re
 }
 
 func (r *DevResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state *DevResourceModel
 
 	// Read Terraform prior state data into the model
@@ -268,15 +766,31 @@ func (r *DevResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	start := time.Now()
+
 	dev := devops_resource.Dev{
 		Id:   state.Id.ValueString(),
 		Name: state.Name.ValueString(),
 		// Engineers: make([]*devops_resource.Engineer, 0),
 	}
 
-	err := r.client.DeleteDev(&dev)
+	err := r.client.DeleteDev(ctx, &dev)
+
+	// Nothing left to delete if the dev is already gone
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "deleting dev", deleteTimeout, start) {
+			return
+		}
 
-	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Dev",
 			"Could not delete dev, unexpected error: "+err.Error(),
@@ -285,6 +799,25 @@ func (r *DevResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+// ImportState accepts a dev id or "dev:<name>". The following Read fills in
+// both engineers and engineer_ids.
 func (r *DevResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	kind, value := parseImportId(req.ID, "dev")
+
+	if kind == "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	dev, err := r.client.FindDevByName(ctx, value)
+	if err != nil {
+		addImportLookupError(&resp.Diagnostics, "dev", req.ID, err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dev.Id)...)
 }
@@ -1,12 +1,44 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+	"terraform-provider-devops-bootcamp/internal/bootcampapi"
 )
 
 func TestDevResource(t *testing.T) {
+	var devId string
+
+	twoEngineersConfig := providerConfig + `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-Bobby"
+	email = "bobby@bobby.com"
+}
+resource "devops-bootcamp_engineer" "test_engineer2" {
+	name  = "tf-acc-BobbysBrother"
+	email = "bobbysBrother@bobby.com"
+}
+resource "devops-bootcamp_dev" "test" {
+	name  = "tf-acc-updatedBobby"
+	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id}, {id = devops-bootcamp_engineer.test_engineer2.id} ]
+}
+`
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -14,13 +46,13 @@ func TestDevResource(t *testing.T) {
 			{
 				Config: providerConfig + `
 resource "devops-bootcamp_dev" "test" {
-	name  = "Bobby"
+	name  = "tf-acc-Bobby"
 	engineers = []
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify name
-					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "Bobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "tf-acc-Bobby"),
 					// Verify email
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("devops-bootcamp_dev.test", "id")),
@@ -30,13 +62,14 @@ resource "devops-bootcamp_dev" "test" {
 
 				Config: providerConfig + `
 resource "devops-bootcamp_dev" "test" {
-	name  = "updatedBobby"
+	name  = "tf-acc-updatedBobby"
 	engineers = []
 }
 			`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "tf-acc-updatedBobby"),
 					resource.TestCheckResourceAttrSet("devops-bootcamp_dev.test", "id"),
+					testAccCaptureId("devops-bootcamp_dev.test", &devId),
 				),
 			},
 			// Add a new engineer
@@ -44,50 +77,808 @@ resource "devops-bootcamp_dev" "test" {
 
 				Config: providerConfig + `
 resource "devops-bootcamp_engineer" "test_engineer" {
-	name  = "Bobby"
+	name  = "tf-acc-Bobby"
 	email = "bobby@bobby.com"
 }
 resource "devops-bootcamp_dev" "test" {
-	name  = "updatedBobby"
+	name  = "tf-acc-updatedBobby"
 	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id} ]
 }
 			`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "tf-acc-updatedBobby"),
 					resource.TestCheckResourceAttrSet("devops-bootcamp_dev.test", "id"),
 					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "1"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.0.id", "devops-bootcamp_engineer.test_engineer", "id"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.0.name", "devops-bootcamp_engineer.test_engineer", "name"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.id", "devops-bootcamp_engineer.test_engineer", "id"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test_engineer", "name"),
 				),
 			},
 			// Add a second engineer
+			{
+
+				Config: twoEngineersConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_dev.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.id", "devops-bootcamp_engineer.test_engineer", "id"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test_engineer", "name"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.id", "devops-bootcamp_engineer.test_engineer2", "id"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test_engineer2", "name"),
+				),
+			},
+			// Reordering the engineers is not a change
 			{
 
 				Config: providerConfig + `
 resource "devops-bootcamp_engineer" "test_engineer" {
-	name  = "Bobby"
+	name  = "tf-acc-Bobby"
 	email = "bobby@bobby.com"
 }
 resource "devops-bootcamp_engineer" "test_engineer2" {
-	name  = "BobbysBrother"
+	name  = "tf-acc-BobbysBrother"
 	email = "bobbysBrother@bobby.com"
 }
 resource "devops-bootcamp_dev" "test" {
-	name  = "updatedBobby"
-	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id}, {id = devops-bootcamp_engineer.test_engineer2.id} ]
+	name  = "tf-acc-updatedBobby"
+	engineers = [ {id = devops-bootcamp_engineer.test_engineer2.id}, {id = devops-bootcamp_engineer.test_engineer.id} ]
 }
 			`,
+				PlanOnly: true,
+			},
+			// Import by name
+			{
+				ResourceName:            "devops-bootcamp_dev.test",
+				ImportState:             true,
+				ImportStateId:           "dev:tf-acc-updatedBobby",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Import by id
+			{
+				ResourceName:            "devops-bootcamp_dev.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Renaming the dev and dropping an engineer outside of Terraform is
+			// detected and reverted
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					dev, err := client.GetDevById(ctx, devId)
+					if err != nil {
+						return err
+					}
+					dev.Name = "tf-acc-updatedBobbyDrifted"
+					dev.Engineers = dev.Engineers[:1]
+					_, err = client.UpdateDev(ctx, dev)
+					return err
+				}),
+				Config:             twoEngineersConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_dev.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: twoEngineersConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "updatedBobby"),
-					resource.TestCheckResourceAttrSet("devops-bootcamp_dev.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "name", "tf-acc-updatedBobby"),
 					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "2"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.0.id", "devops-bootcamp_engineer.test_engineer", "id"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.0.name", "devops-bootcamp_engineer.test_engineer", "name"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.1.id", "devops-bootcamp_engineer.test_engineer2", "id"),
-					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.1.name", "devops-bootcamp_engineer.test_engineer2", "name"),
+					testAccCheckAPI(func(ctx context.Context, client *Client) error {
+						dev, err := client.GetDevById(ctx, devId)
+						if err != nil {
+							return err
+						}
+						if dev.Name != "tf-acc-updatedBobby" || len(dev.Engineers) != 2 {
+							return fmt.Errorf("expected the dev to be reverted, got %s with %d engineers", dev.Name, len(dev.Engineers))
+						}
+						return nil
+					}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestDevResourceNameConflict(t *testing.T) {
+	existing := `
+resource "devops-bootcamp_dev" "existing" {
+	name      = "tf-acc-BobbyTeam"
+	engineers = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + existing,
+			},
+			// A second dev with the same name fails the plan
+			{
+				Config: providerConfig + existing + `
+resource "devops-bootcamp_dev" "duplicate" {
+	name      = "tf-acc-BobbyTeam"
+	engineers = []
+}
+`,
+				ExpectError: regexp.MustCompile(`Duplicate Dev Name`),
+			},
+		},
+	})
+}
+
+func TestDevResourcePlannedEngineers(t *testing.T) {
+	engineers := func(name string) string {
+		return fmt.Sprintf(`
+resource "devops-bootcamp_engineer" "test" {
+	name  = %q
+	email = "bobbyPlanned@bobby.com"
+}
+resource "devops-bootcamp_engineer" "test2" {
+	name  = "tf-acc-BobbyPlannedBrother"
+	email = "bobbyPlannedBrother@bobby.com"
+}
+`, name)
+	}
+	devTwoMembers := `
+resource "devops-bootcamp_dev" "test" {
+	name      = "tf-acc-BobbyPlannedTeam"
+	engineers = [ {id = devops-bootcamp_engineer.test.id}, {id = devops-bootcamp_engineer.test2.id} ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + engineers("tf-acc-BobbyPlanned") + `
+resource "devops-bootcamp_dev" "test" {
+	name      = "tf-acc-BobbyPlannedTeam"
+	engineers = [ {id = devops-bootcamp_engineer.test.id} ]
+}
+`,
+				Check: resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test", "name"),
+			},
+			// Changing the membership plans the details of every member, the
+			// engineer renamed in the same run keeps its planned name
+			{
+				Config: providerConfig + engineers("tf-acc-BobbyPlannedRenamed") + devTwoMembers,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectKnownEngineerDetails("devops-bootcamp_dev.test"),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("devops-bootcamp_dev.test", "engineers.*", map[string]string{
+						"name":  "tf-acc-BobbyPlanned",
+						"email": "bobbyPlanned@bobby.com",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test2", "name"),
+				),
+			},
+			// The next refresh picks up the new name without planning a change
+			{
+				Config: providerConfig + engineers("tf-acc-BobbyPlannedRenamed") + devTwoMembers,
+				Check: resource.TestCheckTypeSetElemNestedAttrs("devops-bootcamp_dev.test", "engineers.*", map[string]string{
+					"name":  "tf-acc-BobbyPlannedRenamed",
+					"email": "bobbyPlanned@bobby.com",
+				}),
+			},
+		},
+	})
+}
+
+func TestDevResourcePlanEngineerDetails(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"endpoint":          tftypes.NewValue(tftypes.String, testAccEndpoint),
+			"skip_health_check": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range configured.Diagnostics {
+		t.Fatalf("unexpected configure diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// Engineers on the API, one already in the group and one to add to it
+	createEngineer := func(name string, email string) *devops_resource.Engineer {
+		var engineer *devops_resource.Engineer
+		err := testAccWithClient(func(ctx context.Context, client *Client) (err error) {
+			engineer, err = client.CreateEngineer(ctx, name, email)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = testAccWithClient(func(ctx context.Context, client *Client) error {
+				return client.DeleteEngineer(ctx, engineer)
+			})
+		})
+
+		return engineer
+	}
+	member := createEngineer("tf-acc-BobbyPlan", "bobbyPlan@bobby.com")
+	added := createEngineer("tf-acc-BobbyPlanAdded", "bobbyPlanAdded@bobby.com")
+
+	schema := schemas.ResourceSchemas["devops-bootcamp_dev"]
+	objectType := schema.ValueType().(tftypes.Object)
+	engineersType := objectType.AttributeTypes["engineers"].(tftypes.Set)
+	engineerType := engineersType.ElementType.(tftypes.Object)
+
+	engineer := func(id string, name any, email any) tftypes.Value {
+		return testObjectValue(engineerType, map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, id),
+			"name":  tftypes.NewValue(tftypes.String, name),
+			"email": tftypes.NewValue(tftypes.String, email),
+		})
+	}
+	engineers := func(elements ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(engineersType, elements)
+	}
+	dev := func(name string, engineers tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":           tftypes.NewValue(tftypes.String, "dev-1"),
+			"name":         tftypes.NewValue(tftypes.String, name),
+			"engineers":    engineers,
+			"last_updated": tftypes.NewValue(tftypes.String, "Monday, 01-Jan-24 00:00:00 UTC"),
+		}
+	}
+
+	refreshedMember := engineer(member.Id, member.Name, member.Email)
+	prior := dev("tf-acc-BobbyPlanTeam", engineers(refreshedMember))
+
+	cases := map[string]struct {
+		name          string
+		proposed      tftypes.Value
+		wantEngineers tftypes.Value
+	}{
+		"unchanged dev keeps the refreshed details": {
+			name:          "tf-acc-BobbyPlanTeam",
+			proposed:      engineers(refreshedMember),
+			wantEngineers: engineers(refreshedMember),
+		},
+		"renamed dev keeps the refreshed details": {
+			name:          "tf-acc-BobbyPlanTeamRenamed",
+			proposed:      engineers(refreshedMember),
+			wantEngineers: engineers(refreshedMember),
+		},
+		"added member is resolved from the API": {
+			name:          "tf-acc-BobbyPlanTeam",
+			proposed:      engineers(refreshedMember, engineer(added.Id, nil, nil)),
+			wantEngineers: engineers(refreshedMember, engineer(added.Id, added.Name, added.Email)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var configured []tftypes.Value
+			var ids []tftypes.Value
+			if err := tc.proposed.As(&configured); err != nil {
+				t.Fatal(err)
+			}
+			for _, element := range configured {
+				var attributes map[string]tftypes.Value
+				if err := element.As(&attributes); err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, testObjectValue(engineerType, map[string]tftypes.Value{"id": attributes["id"]}))
+			}
+
+			config := map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, tc.name),
+				"engineers": engineers(ids...),
+			}
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "devops-bootcamp_dev",
+				PriorState:       testDynamicValue(t, schema, prior),
+				ProposedNewState: testDynamicValue(t, schema, dev(tc.name, tc.proposed)),
+				Config:           testDynamicValue(t, schema, config),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected plan diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			planned, err := resp.PlannedState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := planned.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+
+			if !attributes["engineers"].Equal(tc.wantEngineers) {
+				t.Errorf("expected planned engineers %s, got %s", tc.wantEngineers, attributes["engineers"])
+			}
+		})
+	}
+}
+
+func TestAppliedEngineers(t *testing.T) {
+	// The API already renders the engineer renamed in the same apply
+	engineers := []*devops_resource.Engineer{
+		{Id: "eng-1", Name: "tf-acc-BobbyRenamed", Email: "bobby@bobby.com"},
+		{Id: "eng-2", Name: "tf-acc-BobbysBrother", Email: "bobbysBrother@bobby.com"},
+	}
+	planned := []EngineerModel{
+		{Id: types.StringValue("eng-1"), Name: types.StringValue("tf-acc-Bobby"), Email: types.StringValue("bobby@bobby.com")},
+		{Id: types.StringValue("eng-2"), Name: types.StringUnknown(), Email: types.StringUnknown()},
+	}
+
+	applied := appliedEngineers(engineers, planned)
+
+	want := []EngineerModel{
+		{Id: types.StringValue("eng-1"), Name: types.StringValue("tf-acc-Bobby"), Email: types.StringValue("bobby@bobby.com")},
+		{Id: types.StringValue("eng-2"), Name: types.StringValue("tf-acc-BobbysBrother"), Email: types.StringValue("bobbysBrother@bobby.com")},
+	}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("expected %v, got %v", want, applied)
+	}
+}
+
+// expectKnownEngineerDetails checks that the name and email of every planned
+// engineer of a dev resource are known before apply.
+func expectKnownEngineerDetails(address string) plancheck.PlanCheck {
+	return knownEngineerDetailsCheck{address: address}
+}
+
+type knownEngineerDetailsCheck struct {
+	address string
+}
+
+func (c knownEngineerDetailsCheck) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, change := range req.Plan.ResourceChanges {
+		if change.Address != c.address {
+			continue
+		}
+
+		// Unknown values are left out of After
+		after, _ := change.Change.After.(map[string]any)
+		engineers, _ := after["engineers"].([]any)
+		for _, element := range engineers {
+			engineer, _ := element.(map[string]any)
+			if engineer["name"] == nil || engineer["email"] == nil {
+				resp.Error = fmt.Errorf("%s: expected known engineer details, got %v", c.address, engineer)
+				return
+			}
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s: no planned change", c.address)
+}
+
+func TestDevResourceUnknownEngineer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_dev" "test" {
+	name      = "tf-acc-BobbyGhosts"
+	engineers = [ {id = "does-not-exist"} ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`No engineer with id does-not-exist exists`),
+			},
+		},
+	})
+}
+
+func TestDevResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idsType := schemas.ResourceSchemas["devops-bootcamp_dev"].ValueType().(tftypes.Object).AttributeTypes["engineer_ids"]
+
+	cases := map[string]struct {
+		engineerIds tftypes.Value
+		wantSummary string
+	}{
+		"unique ids": {
+			engineerIds: tftypes.NewValue(idsType, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "1"),
+				tftypes.NewValue(tftypes.String, "2"),
+			}),
+		},
+		"duplicate id": {
+			engineerIds: tftypes.NewValue(idsType, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "1"),
+				tftypes.NewValue(tftypes.String, "2"),
+				tftypes.NewValue(tftypes.String, "1"),
+			}),
+			wantSummary: "Duplicate Engineer",
+		},
+		"unknown ids": {
+			engineerIds: tftypes.NewValue(idsType, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		},
+		"no engineers": {
+			engineerIds: tftypes.NewValue(idsType, nil),
+			wantSummary: "Invalid Attribute Combination",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "devops-bootcamp_dev",
+				Config: testDynamicValue(t, schemas.ResourceSchemas["devops-bootcamp_dev"], map[string]tftypes.Value{
+					"name":         tftypes.NewValue(tftypes.String, "Bobby"),
+					"engineer_ids": tc.engineerIds,
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var summaries []string
+			for _, d := range resp.Diagnostics {
+				summaries = append(summaries, d.Summary)
+			}
+
+			if tc.wantSummary == "" && len(summaries) > 0 {
+				t.Errorf("unexpected diagnostics: %v", summaries)
+			}
+			if tc.wantSummary != "" && (len(summaries) != 1 || summaries[0] != tc.wantSummary) {
+				t.Errorf("expected a single %q diagnostic, got %v", tc.wantSummary, summaries)
+			}
+		})
+	}
+}
+
+func TestDevResourceEngineerIds(t *testing.T) {
+	engineers := `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-BobbyIds"
+	email = "bobbyIds@bobby.com"
+}
+resource "devops-bootcamp_engineer" "test_engineer2" {
+	name  = "tf-acc-BobbyIdsBrother"
+	email = "bobbyIdsBrother@bobby.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_dev" "test" {
+	name         = "tf-acc-BobbyIdsTeam"
+	engineer_ids = ["64b0c9", "64b0ca", "64b0c9"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Duplicate Engineer`),
+			},
+			{
+				Config: providerConfig + engineers + `
+resource "devops-bootcamp_dev" "test" {
+	name         = "tf-acc-BobbyIdsTeam"
+	engineer_ids = [devops-bootcamp_engineer.test_engineer.id, devops-bootcamp_engineer.test_engineer2.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineer_ids.#", "2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.id", "devops-bootcamp_engineer.test_engineer", "id"),
+					resource.TestCheckTypeSetElemAttrPair("devops-bootcamp_dev.test", "engineers.*.name", "devops-bootcamp_engineer.test_engineer2", "name"),
+				),
+			},
+			// Removing an engineer from the list
+			{
+				Config: providerConfig + engineers + `
+resource "devops-bootcamp_dev" "test" {
+	name         = "tf-acc-BobbyIdsTeam"
+	engineer_ids = [devops-bootcamp_engineer.test_engineer2.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev.test", "engineers.#", "1"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_dev.test", "engineers.0.id", "devops-bootcamp_engineer.test_engineer2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDevResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Version 0 state with engineers stored as a list, including a duplicate
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "devops-bootcamp_dev",
+		Version:  0,
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{
+				"id": "dev-1",
+				"name": "Bobby",
+				"last_updated": "Monday, 01-Jan-24 00:00:00 UTC",
+				"engineers": [
+					{"id": "eng-2", "name": "BobbysBrother", "email": "bobbysBrother@bobby.com", "last_updated": null},
+					{"id": "eng-1", "name": "Bobby", "email": "bobby@bobby.com", "last_updated": null},
+					{"id": "eng-1", "name": "Bobby", "email": "bobby@bobby.com", "last_updated": null}
+				]
+			}`),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	state, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas["devops-bootcamp_dev"].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if !attributes["engineers"].Type().Is(tftypes.Set{}) {
+		t.Fatalf("expected engineers to be a set, got %s", attributes["engineers"].Type())
+	}
+
+	var engineers []tftypes.Value
+	if err := attributes["engineers"].As(&engineers); err != nil {
+		t.Fatal(err)
+	}
+	if len(engineers) != 2 {
+		t.Errorf("expected 2 engineers after removing the duplicate, got %d", len(engineers))
+	}
+
+	// Without a previous order the ids are sorted
+	wantIds := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "eng-1"),
+		tftypes.NewValue(tftypes.String, "eng-2"),
+	})
+	if !attributes["engineer_ids"].Equal(wantIds) {
+		t.Errorf("expected engineer_ids %s, got %s", wantIds, attributes["engineer_ids"])
+	}
+}
+
+func TestEngineerIdsValue(t *testing.T) {
+	prior := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("c"),
+		types.StringValue("gone"),
+		types.StringValue("a"),
+	})
+
+	got := engineerIdsValue([]string{"a", "d", "b", "c"}, prior)
+
+	want := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("c"),
+		types.StringValue("a"),
+		types.StringValue("b"),
+		types.StringValue("d"),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDevResourceReadEngineerIds(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"endpoint":          tftypes.NewValue(tftypes.String, testAccEndpoint),
+			"skip_health_check": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range configured.Diagnostics {
+		t.Fatalf("unexpected configure diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// A dev group of two engineers on the API
+	var dev *devops_resource.Dev
+	var ids []string
+	err = testAccWithClient(func(ctx context.Context, client *Client) error {
+		group := &devops_resource.Dev{Name: "tf-acc-BobbyReadIdsTeam"}
+		for _, name := range []string{"tf-acc-BobbyReadIds", "tf-acc-BobbyReadIdsBrother"} {
+			engineer, err := client.CreateEngineer(ctx, name, "bobbyReadIds@bobby.com")
+			if err != nil {
+				return err
+			}
+			t.Cleanup(func() {
+				_ = testAccWithClient(func(ctx context.Context, client *Client) error {
+					return client.DeleteEngineer(ctx, engineer)
+				})
+			})
+			group.Engineers = append(group.Engineers, &devops_resource.Engineer{Id: engineer.Id})
+			ids = append(ids, engineer.Id)
+		}
+
+		var err error
+		dev, err = client.CreateDev(ctx, group)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = testAccWithClient(func(ctx context.Context, client *Client) error {
+			return client.DeleteDev(ctx, dev)
+		})
+	})
+	sort.Strings(ids)
+
+	schema := schemas.ResourceSchemas["devops-bootcamp_dev"]
+	idsType := schema.ValueType().(tftypes.Object).AttributeTypes["engineer_ids"]
+	idsValue := func(ids ...string) tftypes.Value {
+		elements := []tftypes.Value{}
+		for _, id := range ids {
+			elements = append(elements, tftypes.NewValue(tftypes.String, id))
+		}
+		return tftypes.NewValue(idsType, elements)
+	}
+
+	cases := map[string]struct {
+		engineerIds tftypes.Value
+		want        tftypes.Value
+	}{
+		// Import leaves only the id in state
+		"imported": {
+			engineerIds: tftypes.NewValue(idsType, nil),
+			want:        idsValue(ids[0], ids[1]),
+		},
+		"configured order": {
+			engineerIds: idsValue(ids[1], ids[0]),
+			want:        idsValue(ids[1], ids[0]),
+		},
+		"member added outside of Terraform": {
+			engineerIds: idsValue(ids[1]),
+			want:        idsValue(ids[1], ids[0]),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName: "devops-bootcamp_dev",
+				CurrentState: testDynamicValue(t, schema, map[string]tftypes.Value{
+					"id":           tftypes.NewValue(tftypes.String, dev.Id),
+					"engineer_ids": tc.engineerIds,
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected read diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			state, err := resp.NewState.Unmarshal(schema.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := state.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+
+			if !attributes["engineer_ids"].Equal(tc.want) {
+				t.Errorf("expected engineer_ids %s, got %s", tc.want, attributes["engineer_ids"])
+			}
+		})
+	}
+}
+
+func TestDevResourceFaults(t *testing.T) {
+	config := func(name string, timeouts string) string {
+		return testProviderConfig(`max_retries = 0`) + fmt.Sprintf(`
+resource "devops-bootcamp_dev" "test" {
+	name      = %q
+	engineers = []
+	%s
+}
+`, name, timeouts)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckInMemoryAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Dropped connection on create
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPost, "/dev", bootcampapi.Fault{Reset: true, Times: 1})
+				},
+				Config:      config("tf-acc-FaultyTeam", ""),
+				ExpectError: regexp.MustCompile(`(?s)Error creating dev.*Could not create dev.*(connection reset by peer|EOF)`),
+			},
+			{
+				Config: config("tf-acc-FaultyTeam", ""),
+			},
+			// Error status on update
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPut, "/dev/", bootcampapi.Fault{Status: http.StatusInternalServerError, Body: "boom", Times: 1})
+				},
+				Config:      config("tf-acc-RenamedFaultyTeam", ""),
+				ExpectError: regexp.MustCompile(`(?s)Error updating dev.*status: 500, body: boom`),
+			},
+			// Slow update
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPut, "/dev/", bootcampapi.Fault{Latency: 5 * time.Second, Times: 1})
+				},
+				Config:      config("tf-acc-RenamedFaultyTeam", `timeouts { update = "1s" }`),
+				ExpectError: regexp.MustCompile(`Timeout updating dev`),
+			},
+			// Malformed response on refresh
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodGet, "/dev/id/", bootcampapi.Fault{Truncate: true, Times: 1})
+				},
+				Config:      config("tf-acc-FaultyTeam", ""),
+				ExpectError: regexp.MustCompile(`(?s)Client Error.*unexpected end of JSON input`),
+			},
+		},
+	})
+}
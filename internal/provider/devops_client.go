@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Function to create a devops
+func (c *Client) CreateDevOps(ctx context.Context, devops *devops_resource.DevOps) (*devops_resource.DevOps, error) {
+	reqBody, err := json.Marshal(devops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/devops", c.HostURL), bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newDevOps := devops_resource.DevOps{}
+
+	err = json.Unmarshal(res, &newDevOps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newDevOps, nil
+}
+
+func (c *Client) GetDevOpsById(ctx context.Context, id string) (*devops_resource.DevOps, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/devops/id/%s", c.HostURL, id), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	devops := devops_resource.DevOps{}
+
+	err = json.Unmarshal(body, &devops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &devops, nil
+}
+
+func (c *Client) DeleteDevOps(ctx context.Context, devops *devops_resource.DevOps) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/devops/%s", c.HostURL, devops.Id), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DoRequest(req)
+
+	return err
+}
+
+func (c *Client) UpdateDevOps(ctx context.Context, devops *devops_resource.DevOps) (*devops_resource.DevOps, error) {
+	reqBody, err := json.Marshal(devops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/devops/%s", c.HostURL, devops.Id), bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newDevOps := devops_resource.DevOps{}
+
+	err = json.Unmarshal(res, &newDevOps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newDevOps, nil
+}
+
+func (c *Client) ListDevOps(ctx context.Context) ([]*devops_resource.DevOps, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/devops", c.HostURL), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	devops := []*devops_resource.DevOps{}
+
+	err = json.Unmarshal(body, &devops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return devops, nil
+}
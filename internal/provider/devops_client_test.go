@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestDevOpsClient(t *testing.T) {
+	client := cassetteClient(t)
+	ctx := context.Background()
+
+	dev, err := client.CreateDev(ctx, &devops_resource.Dev{Name: "tf-acc-CassetteDevOpsDevs", Engineers: []*devops_resource.Engineer{}})
+	if err != nil {
+		t.Fatalf("CreateDev: %s", err)
+	}
+
+	ops, err := client.CreateOps(ctx, &devops_resource.Ops{Name: "tf-acc-CassetteDevOpsOps", Engineers: []*devops_resource.Engineer{}})
+	if err != nil {
+		t.Fatalf("CreateOps: %s", err)
+	}
+
+	created, err := client.CreateDevOps(ctx, &devops_resource.DevOps{
+		Devs: []*devops_resource.Dev{{Id: dev.Id}},
+		Ops:  []*devops_resource.Ops{},
+	})
+	if err != nil {
+		t.Fatalf("CreateDevOps: %s", err)
+	}
+	if created.Id == "" || len(created.Devs) != 1 || created.Devs[0].Name != "tf-acc-CassetteDevOpsDevs" || len(created.Ops) != 0 {
+		t.Fatalf("CreateDevOps: unexpected devops %+v", created)
+	}
+
+	updated, err := client.UpdateDevOps(ctx, &devops_resource.DevOps{
+		Id:   created.Id,
+		Devs: []*devops_resource.Dev{{Id: dev.Id}},
+		Ops:  []*devops_resource.Ops{{Id: ops.Id}},
+	})
+	if err != nil || len(updated.Ops) != 1 || updated.Ops[0].Name != "tf-acc-CassetteDevOpsOps" {
+		t.Fatalf("UpdateDevOps: unexpected devops %+v, %v", updated, err)
+	}
+
+	devops, err := client.GetDevOpsById(ctx, created.Id)
+	if err != nil || len(devops.Devs) != 1 || len(devops.Ops) != 1 {
+		t.Fatalf("GetDevOpsById: unexpected devops %+v, %v", devops, err)
+	}
+
+	all, err := client.ListDevOps(ctx)
+	if err != nil || findById(all, created.Id, func(devops *devops_resource.DevOps) string { return devops.Id }) == nil {
+		t.Fatalf("ListDevOps: expected %s among %v, %v", created.Id, all, err)
+	}
+
+	if err := client.DeleteDevOps(ctx, created); err != nil {
+		t.Fatalf("DeleteDevOps: %s", err)
+	}
+
+	_, err = client.GetDevOpsById(ctx, created.Id)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetDevOpsById: expected ErrNotFound after delete, got %v", err)
+	}
+
+	if err := client.DeleteOps(ctx, ops); err != nil {
+		t.Fatalf("DeleteOps: %s", err)
+	}
+
+	if err := client.DeleteDev(ctx, dev); err != nil {
+		t.Fatalf("DeleteDev: %s", err)
+	}
+}
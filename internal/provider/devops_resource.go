@@ -0,0 +1,398 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DevOpsResource{}
+var _ resource.ResourceWithImportState = &DevOpsResource{}
+
+func NewDevOpsResource() resource.Resource {
+	return &DevOpsResource{}
+}
+
+// DevOpsResource defines the resource implementation.
+type DevOpsResource struct {
+	client *Client
+}
+
+// DevOpsResourceModel describes the resource data model.
+type DevOpsResourceModel struct {
+	Id          types.String       `tfsdk:"id"`
+	Dev         []DevOpsGroupModel `tfsdk:"dev"`
+	Ops         []DevOpsGroupModel `tfsdk:"ops"`
+	LastUpdated types.String       `tfsdk:"last_updated"`
+}
+
+// DevOpsGroupModel describes a dev or ops group referenced by a devops team.
+// Engineers is a types.List because it is computed and may be unknown during plan.
+type DevOpsGroupModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Engineers types.List   `tfsdk:"engineers"`
+}
+
+func (r *DevOpsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devops"
+}
+
+func (r *DevOpsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevOps team resource composed of developer and operations groups",
+
+		Attributes: map[string]schema.Attribute{
+			"dev": devOpsGroupSchema("List of developer groups in the devops team by id"),
+			"ops": devOpsGroupSchema("List of operations groups in the devops team by id"),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "DevOps identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// devOpsGroupSchema returns the schema of a list of groups referenced by id,
+// whose name and engineers are expanded from the API.
+func devOpsGroupSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Required:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Required: true,
+				},
+				"name": schema.StringAttribute{
+					Computed: true,
+				},
+				"engineers": schema.ListNestedAttribute{
+					Computed: true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.StringAttribute{
+								Computed: true,
+							},
+							"name": schema.StringAttribute{
+								Computed: true,
+							},
+							"email": schema.StringAttribute{
+								Computed: true,
+							},
+							"last_updated": schema.StringAttribute{
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DevOpsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DevOpsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var planned *DevOpsResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	reqObj := devOpsRequest(planned)
+
+	created, err := r.client.CreateDevOps(ctx, reqObj)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating devops",
+			"Could not create devops,unexpected error:"+err.Error(),
+		)
+		return
+	}
+
+	// Fetch the created devops from the API so the groups are expanded
+	devops, err := r.client.GetDevOpsById(ctx, created.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read devops, got error: %s", err))
+		return
+	}
+
+	// Map the response to the planned model
+	resp.Diagnostics.Append(setDevOpsModel(ctx, planned, devops)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a devops resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planned)...)
+}
+
+func (r *DevOpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !clientRefreshable(ctx, r.client) {
+		return
+	}
+
+	var state *DevOpsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devops, err := r.client.GetDevOpsById(ctx, state.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// The devops was deleted outside of Terraform, remove it from state so it is recreated
+			tflog.Warn(ctx, fmt.Sprintf("devops %s not found, removing from state", state.Id))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read devops, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(setDevOpsModel(ctx, state, devops)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read resource for id:%s", state.Id))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DevOpsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var planned *DevOpsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	reqObj := devOpsRequest(planned)
+	reqObj.Id = planned.Id.ValueString()
+
+	// update the devops
+	_, err := r.client.UpdateDevOps(ctx, reqObj)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating devops",
+			"Could not update devops,unexpected error:"+err.Error(),
+		)
+		return
+	}
+
+	// Fetch the updated devops from the API
+	devops, err := r.client.GetDevOpsById(ctx, planned.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read devops, got error: %s", err))
+		return
+	}
+
+	// Update the planned model with the updated devops
+	resp.Diagnostics.Append(setDevOpsModel(ctx, planned, devops)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	tflog.Trace(ctx, "updated a devops resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planned)...)
+}
+
+func (r *DevOpsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state *DevOpsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devops := devops_resource.DevOps{
+		Id: state.Id.ValueString(),
+	}
+
+	err := r.client.DeleteDevOps(ctx, &devops)
+
+	// Nothing left to delete if the devops is already gone
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting DevOps",
+			"Could not delete devops, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *DevOpsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// devOpsRequest builds the API request body from the group ids in the model.
+func devOpsRequest(model *DevOpsResourceModel) *devops_resource.DevOps {
+	reqObj := devops_resource.DevOps{
+		Devs: make([]*devops_resource.Dev, 0),
+		Ops:  make([]*devops_resource.Ops, 0),
+	}
+
+	for _, dev := range model.Dev {
+		reqObj.Devs = append(reqObj.Devs, &devops_resource.Dev{
+			Id: dev.Id.ValueString(),
+		})
+	}
+
+	for _, ops := range model.Ops {
+		reqObj.Ops = append(reqObj.Ops, &devops_resource.Ops{
+			Id: ops.Id.ValueString(),
+		})
+	}
+
+	return &reqObj
+}
+
+// setDevOpsModel maps a devops returned by the API, including the engineers
+// of every nested group, onto the model.
+func setDevOpsModel(ctx context.Context, model *DevOpsResourceModel, devops *devops_resource.DevOps) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Id = types.StringValue(devops.Id)
+
+	// The API may return the groups in any order, keep the configured one
+	devs := inModelOrder(model.Dev, devops.Devs, func(dev *devops_resource.Dev) string { return dev.Id })
+	opsGroups := inModelOrder(model.Ops, devops.Ops, func(ops *devops_resource.Ops) string { return ops.Id })
+
+	model.Dev = []DevOpsGroupModel{}
+	for _, dev := range devs {
+		engineers, d := engineersListValue(ctx, dev.Engineers)
+		diags.Append(d...)
+
+		model.Dev = append(model.Dev, DevOpsGroupModel{
+			Id:        types.StringValue(dev.Id),
+			Name:      types.StringValue(dev.Name),
+			Engineers: engineers,
+		})
+	}
+
+	model.Ops = []DevOpsGroupModel{}
+	for _, ops := range opsGroups {
+		engineers, d := engineersListValue(ctx, ops.Engineers)
+		diags.Append(d...)
+
+		model.Ops = append(model.Ops, DevOpsGroupModel{
+			Id:        types.StringValue(ops.Id),
+			Name:      types.StringValue(ops.Name),
+			Engineers: engineers,
+		})
+	}
+
+	return diags
+}
+
+// inModelOrder returns groups in the order their ids have in model. Groups
+// not in model, such as ones added outside of Terraform, follow in the order
+// the API returned them.
+func inModelOrder[T any](model []DevOpsGroupModel, groups []T, id func(T) string) []T {
+	positions := map[string]int{}
+	for i, group := range model {
+		if _, ok := positions[group.Id.ValueString()]; !ok {
+			positions[group.Id.ValueString()] = i
+		}
+	}
+
+	ordered := make([]T, 0, len(groups))
+	var rest []T
+	for _, group := range groups {
+		if _, ok := positions[id(group)]; ok {
+			ordered = append(ordered, group)
+		} else {
+			rest = append(rest, group)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return positions[id(ordered[i])] < positions[id(ordered[j])]
+	})
+
+	return append(ordered, rest...)
+}
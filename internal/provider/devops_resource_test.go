@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestDevOpsResource(t *testing.T) {
+	var devOpsId string
+
+	withOpsConfig := providerConfig + `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-Bobby"
+	email = "bobby@bobby.com"
+}
+resource "devops-bootcamp_dev" "test" {
+	name  = "tf-acc-Bobby"
+	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id} ]
+}
+resource "devops-bootcamp_ops" "test" {
+	name  = "tf-acc-BobbysOps"
+	engineers = []
+}
+resource "devops-bootcamp_devops" "test" {
+	dev = [ {id = devops-bootcamp_dev.test.id} ]
+	ops = [ {id = devops-bootcamp_ops.test.id} ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-Bobby"
+	email = "bobby@bobby.com"
+}
+resource "devops-bootcamp_dev" "test" {
+	name  = "tf-acc-Bobby"
+	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id} ]
+}
+resource "devops-bootcamp_devops" "test" {
+	dev = [ {id = devops-bootcamp_dev.test.id} ]
+	ops = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("devops-bootcamp_devops.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "dev.#", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "ops.#", "0"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_devops.test", "dev.0.id", "devops-bootcamp_dev.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "dev.0.name", "tf-acc-Bobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "dev.0.engineers.#", "1"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_devops.test", "dev.0.engineers.0.id", "devops-bootcamp_engineer.test_engineer", "id"),
+				),
+			},
+			// Add an ops group
+			{
+				Config: withOpsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "dev.#", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "ops.#", "1"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_devops.test", "ops.0.id", "devops-bootcamp_ops.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "ops.0.name", "tf-acc-BobbysOps"),
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "ops.0.engineers.#", "0"),
+					testAccCaptureId("devops-bootcamp_devops.test", &devOpsId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "devops-bootcamp_devops.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Removing the ops group outside of Terraform is detected and reverted
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					devops, err := client.GetDevOpsById(ctx, devOpsId)
+					if err != nil {
+						return err
+					}
+					devops.Ops = []*devops_resource.Ops{}
+					_, err = client.UpdateDevOps(ctx, devops)
+					return err
+				}),
+				Config:             withOpsConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_devops.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: withOpsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_devops.test", "ops.#", "1"),
+					testAccCheckAPI(func(ctx context.Context, client *Client) error {
+						devops, err := client.GetDevOpsById(ctx, devOpsId)
+						if err != nil {
+							return err
+						}
+						if len(devops.Ops) != 1 {
+							return fmt.Errorf("expected the ops group to be added back, got %d ops groups", len(devops.Ops))
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSetDevOpsModelKeepsOrder(t *testing.T) {
+	group := func(id string) DevOpsGroupModel {
+		return DevOpsGroupModel{Id: types.StringValue(id)}
+	}
+
+	model := &DevOpsResourceModel{
+		Dev: []DevOpsGroupModel{group("dev-2"), group("dev-1")},
+		Ops: []DevOpsGroupModel{group("ops-2"), group("ops-1")},
+	}
+
+	// The API returns the groups sorted differently, plus one added outside
+	// of Terraform
+	devops := &devops_resource.DevOps{
+		Id:   "devops-1",
+		Devs: []*devops_resource.Dev{{Id: "dev-3"}, {Id: "dev-1"}, {Id: "dev-2"}},
+		Ops:  []*devops_resource.Ops{{Id: "ops-1"}, {Id: "ops-2"}},
+	}
+
+	if diags := setDevOpsModel(context.Background(), model, devops); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	ids := func(groups []DevOpsGroupModel) []string {
+		var ids []string
+		for _, group := range groups {
+			ids = append(ids, group.Id.ValueString())
+		}
+		return ids
+	}
+
+	if got := ids(model.Dev); !reflect.DeepEqual(got, []string{"dev-2", "dev-1", "dev-3"}) {
+		t.Errorf("expected the configured dev order followed by new groups, got %v", got)
+	}
+	if got := ids(model.Ops); !reflect.DeepEqual(got, []string{"ops-2", "ops-1"}) {
+		t.Errorf("expected the configured ops order, got %v", got)
+	}
+}
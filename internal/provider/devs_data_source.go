@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &DevsDataSource{}
+	_ datasource.DataSourceWithConfigure = &DevsDataSource{}
+)
+
+func NewDevsDataSource() datasource.DataSource {
+	return &DevsDataSource{}
+}
+
+// DevsDataSource defines the data source implementation.
+type DevsDataSource struct {
+	client *Client
+}
+
+// DevsModel describes the data source data model.
+type DevsModel struct {
+	Id            types.String   `tfsdk:"id"`
+	NamePrefix    types.String   `tfsdk:"name_prefix"`
+	EngineerId    types.String   `tfsdk:"engineer_id"`
+	Devs          []DevsDevModel `tfsdk:"devs"`
+	DevCount      types.Int64    `tfsdk:"dev_count"`
+	EngineerCount types.Int64    `tfsdk:"engineer_count"`
+}
+
+// DevsDevModel describes a developer group listed by the data source.
+type DevsDevModel struct {
+	Id            types.String    `tfsdk:"id"`
+	Name          types.String    `tfsdk:"name"`
+	Engineers     []EngineerModel `tfsdk:"engineers"`
+	EngineerCount types.Int64     `tfsdk:"engineer_count"`
+}
+
+func (d *DevsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devs"
+}
+
+func (d *DevsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists all developer groups, optionally filtered",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return developer groups whose name starts with this prefix",
+				Optional:            true,
+			},
+			"engineer_id": schema.StringAttribute{
+				MarkdownDescription: "Only return developer groups that contain the engineer with this id",
+				Optional:            true,
+			},
+			"devs": schema.ListNestedAttribute{
+				MarkdownDescription: "List of developer groups matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"engineers": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed: true,
+									},
+									"name": schema.StringAttribute{
+										Computed: true,
+									},
+									"email": schema.StringAttribute{
+										Computed: true,
+									},
+									"last_updated": schema.StringAttribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+						"engineer_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of engineers in the developer group",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"dev_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of developer groups matching the filters",
+				Computed:            true,
+			},
+			"engineer_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of distinct engineers across the developer groups matching the filters",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DevsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DevsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !clientConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state DevsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devs, err := d.client.ListDevs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list devs, got error: %s", err))
+		return
+	}
+
+	state.Devs = []DevsDevModel{}
+	members := map[string]bool{}
+	for _, dev := range devs {
+		if !strings.HasPrefix(dev.Name, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if !state.EngineerId.IsNull() && !engineerIds(dev.Engineers)[state.EngineerId.ValueString()] {
+			continue
+		}
+
+		engineers := []EngineerModel{}
+		for _, engineer := range dev.Engineers {
+			engineers = append(engineers, EngineerModel{
+				Id:    types.StringValue(engineer.Id),
+				Name:  types.StringValue(engineer.Name),
+				Email: types.StringValue(engineer.Email),
+			})
+			members[engineer.Id] = true
+		}
+
+		state.Devs = append(state.Devs, DevsDevModel{
+			Id:            types.StringValue(dev.Id),
+			Name:          types.StringValue(dev.Name),
+			Engineers:     engineers,
+			EngineerCount: types.Int64Value(int64(len(engineers))),
+		})
+	}
+
+	state.Id = types.StringValue("devs")
+	state.DevCount = types.Int64Value(int64(len(state.Devs)))
+	state.EngineerCount = types.Int64Value(int64(len(members)))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read %d devs", len(state.Devs)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
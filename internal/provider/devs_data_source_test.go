@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDevsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccDevsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.by_prefix", "dev_count", "2"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.by_prefix", "devs.#", "2"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.by_prefix", "engineer_count", "1"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.by_engineer", "dev_count", "1"),
+					resource.TestCheckResourceAttrPair("data.devops-bootcamp_devs.by_engineer", "devs.0.id", "devops-bootcamp_dev.frontend", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.by_engineer", "devs.0.engineer_count", "1"),
+					resource.TestCheckResourceAttrPair("data.devops-bootcamp_devs.by_engineer", "devs.0.engineers.0.email", "devops-bootcamp_engineer.test", "email"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDevsDataSourceConfig = `
+
+	resource "devops-bootcamp_engineer" "test" {
+		name  = "tf-acc-RyanDevs"
+		email = "ryan@devs.example.com"
+	}
+
+	resource "devops-bootcamp_dev" "frontend" {
+		name      = "tf-acc-devs-test-frontend"
+		engineers = [ {id = devops-bootcamp_engineer.test.id} ]
+	}
+
+	resource "devops-bootcamp_dev" "backend" {
+		name      = "tf-acc-devs-test-backend"
+		engineers = []
+	}
+
+	data "devops-bootcamp_devs" "by_prefix" {
+		name_prefix = "tf-acc-devs-test-"
+		depends_on  = [devops-bootcamp_dev.frontend, devops-bootcamp_dev.backend]
+	}
+
+	data "devops-bootcamp_devs" "by_engineer" {
+		engineer_id = devops-bootcamp_engineer.test.id
+		depends_on  = [devops-bootcamp_dev.frontend, devops-bootcamp_dev.backend]
+	}
+
+`
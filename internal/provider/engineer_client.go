@@ -2,15 +2,17 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
 )
 
-func (c *Client) GetEngineer(Id string) (*devops_resource.Engineer, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers/id/%s", c.HostURL, Id), nil)
+func (c *Client) GetEngineer(ctx context.Context, Id string) (*devops_resource.Engineer, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/engineers/id/%s", c.HostURL, Id), nil)
 
 	if err != nil {
 		return nil, err
@@ -32,8 +34,8 @@ func (c *Client) GetEngineer(Id string) (*devops_resource.Engineer, error) {
 	return &engineer, nil
 }
 
-func (c *Client) GetEngineerByName(name string) (*devops_resource.Engineer, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers/name/%s", c.HostURL, name), nil)
+func (c *Client) GetEngineerByName(ctx context.Context, name string) (*devops_resource.Engineer, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/engineers/name/%s", c.HostURL, name), nil)
 
 	if err != nil {
 		return nil, err
@@ -56,7 +58,7 @@ func (c *Client) GetEngineerByName(name string) (*devops_resource.Engineer, erro
 	return &engineer, nil
 }
 
-func (c *Client) CreateEngineer(name string, email string) (*devops_resource.Engineer, error) {
+func (c *Client) CreateEngineer(ctx context.Context, name string, email string) (*devops_resource.Engineer, error) {
 	newEngineer := devops_resource.Engineer{
 		Name:  name,
 		Email: email,
@@ -67,7 +69,7 @@ func (c *Client) CreateEngineer(name string, email string) (*devops_resource.Eng
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/engineers", c.HostURL), bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/engineers", c.HostURL), bytes.NewBuffer(jsonBody))
 
 	if err != nil {
 		return nil, err
@@ -90,7 +92,7 @@ func (c *Client) CreateEngineer(name string, email string) (*devops_resource.Eng
 	return &engineer, nil
 }
 
-func (c *Client) UpdateEngineer(id string, name string, email string) (*devops_resource.Engineer, error) {
+func (c *Client) UpdateEngineer(ctx context.Context, id string, name string, email string) (*devops_resource.Engineer, error) {
 	engineer := devops_resource.Engineer{
 		Name:  name,
 		Email: email,
@@ -103,7 +105,7 @@ func (c *Client) UpdateEngineer(id string, name string, email string) (*devops_r
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/engineers/%s", c.HostURL, id), bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/engineers/%s", c.HostURL, id), bytes.NewBuffer(jsonBody))
 
 	if err != nil {
 		return nil, err
@@ -126,8 +128,8 @@ func (c *Client) UpdateEngineer(id string, name string, email string) (*devops_r
 	return &updatedEngineer, nil
 }
 
-func (c *Client) DeleteEngineer(engineer *devops_resource.Engineer) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/engineers/%s", c.HostURL, engineer.Id), nil)
+func (c *Client) DeleteEngineer(ctx context.Context, engineer *devops_resource.Engineer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/engineers/%s", c.HostURL, engineer.Id), nil)
 
 	if err != nil {
 		return err
@@ -141,3 +143,72 @@ func (c *Client) DeleteEngineer(engineer *devops_resource.Engineer) error {
 
 	return nil
 }
+
+func (c *Client) ListEngineers(ctx context.Context) ([]*devops_resource.Engineer, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/engineers", c.HostURL), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	engineers := []*devops_resource.Engineer{}
+
+	err = json.Unmarshal(body, &engineers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return engineers, nil
+}
+
+// FindEngineerByName returns the only engineer named name. Unlike
+// GetEngineerByName it detects duplicate names, returning an *AmbiguousError.
+func (c *Client) FindEngineerByName(ctx context.Context, name string) (*devops_resource.Engineer, error) {
+	return c.findEngineer(ctx, "name", name, func(engineer *devops_resource.Engineer) bool {
+		return engineer.Name == name
+	})
+}
+
+// FindEngineerByEmail returns the only engineer with email, compared case
+// insensitively, returning an *AmbiguousError if several match.
+func (c *Client) FindEngineerByEmail(ctx context.Context, email string) (*devops_resource.Engineer, error) {
+	return c.findEngineer(ctx, "email", email, func(engineer *devops_resource.Engineer) bool {
+		return strings.EqualFold(engineer.Email, email)
+	})
+}
+
+func (c *Client) findEngineer(ctx context.Context, field string, value string, match func(*devops_resource.Engineer) bool) (*devops_resource.Engineer, error) {
+	engineers, err := c.ListEngineers(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*devops_resource.Engineer
+	for _, engineer := range engineers {
+		if match(engineer) {
+			found = append(found, engineer)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no engineer has %s %q: %w", field, value, ErrNotFound)
+	case 1:
+		return found[0], nil
+	}
+
+	ambiguous := &AmbiguousError{Kind: "engineer", Field: field, Value: value}
+	for _, engineer := range found {
+		ambiguous.Ids = append(ambiguous.Ids, engineer.Id)
+	}
+
+	return nil, ambiguous
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestEngineerClient(t *testing.T) {
+	client := cassetteClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateEngineer(ctx, "tf-acc-CassetteRyan", "ryan@cassette.com")
+	if err != nil {
+		t.Fatalf("CreateEngineer: %s", err)
+	}
+	if created.Id == "" || created.Name != "tf-acc-CassetteRyan" || created.Email != "ryan@cassette.com" {
+		t.Fatalf("CreateEngineer: unexpected engineer %+v", created)
+	}
+
+	engineer, err := client.GetEngineer(ctx, created.Id)
+	if err != nil || *engineer != *created {
+		t.Fatalf("GetEngineer: expected %+v, got %+v, %v", created, engineer, err)
+	}
+
+	engineer, err = client.GetEngineerByName(ctx, "tf-acc-CassetteRyan")
+	if err != nil || engineer.Id != created.Id {
+		t.Fatalf("GetEngineerByName: expected %s, got %+v, %v", created.Id, engineer, err)
+	}
+
+	updated, err := client.UpdateEngineer(ctx, created.Id, "tf-acc-CassetteRyan", "ryan@liatrio.com")
+	if err != nil || updated.Email != "ryan@liatrio.com" {
+		t.Fatalf("UpdateEngineer: unexpected engineer %+v, %v", updated, err)
+	}
+
+	engineers, err := client.ListEngineers(ctx)
+	listed := findById(engineers, updated.Id, func(engineer *devops_resource.Engineer) string { return engineer.Id })
+	if err != nil || listed == nil || *listed != *updated {
+		t.Fatalf("ListEngineers: expected %+v among %v, %v", updated, engineers, err)
+	}
+
+	engineer, err = client.FindEngineerByEmail(ctx, "RYAN@liatrio.com")
+	if err != nil || engineer.Id != created.Id {
+		t.Fatalf("FindEngineerByEmail: expected %s, got %+v, %v", created.Id, engineer, err)
+	}
+
+	engineer, err = client.FindEngineerByName(ctx, "tf-acc-CassetteRyan")
+	if err != nil || engineer.Id != created.Id {
+		t.Fatalf("FindEngineerByName: expected %s, got %+v, %v", created.Id, engineer, err)
+	}
+
+	if err := client.DeleteEngineer(ctx, created); err != nil {
+		t.Fatalf("DeleteEngineer: %s", err)
+	}
+
+	_, err = client.GetEngineer(ctx, created.Id)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetEngineer: expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -5,18 +5,23 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ datasource.DataSource              = &EngineerDataSource{}
-	_ datasource.DataSourceWithConfigure = &EngineerDataSource{}
+	_ datasource.DataSource                     = &EngineerDataSource{}
+	_ datasource.DataSourceWithConfigure        = &EngineerDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &EngineerDataSource{}
 )
 
 func NewEngineerDataSource() datasource.DataSource {
@@ -28,6 +33,16 @@ type EngineerDataSource struct {
 	client *Client
 }
 
+// EngineerDataSourceModel describes the data source data model.
+type EngineerDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Email          types.String `tfsdk:"email"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+	FailIfNotFound types.Bool   `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool   `tfsdk:"exists"`
+}
+
 func (d *EngineerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_engineer"
 }
@@ -35,28 +50,49 @@ func (d *EngineerDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *EngineerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Engineer data source",
+		MarkdownDescription: "Engineer data source, looked up by exactly one of `id`, `name` or `email`",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the Engineer",
 				Optional:            true,
+				Computed:            true,
 			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "Email of the Engineer",
 				Optional:            true,
+				Computed:            true,
 			},
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Example identifier",
+				MarkdownDescription: "Id of the Engineer",
+				Optional:            true,
 				Computed:            true,
 			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"fail_if_not_found": schema.BoolAttribute{
+				MarkdownDescription: "Fail when no engineer matches the lookup. When `false` a missing engineer sets `exists` to `false` and leaves the other attributes null. Defaults to `true`.",
+				Optional:            true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether an engineer matching the lookup exists",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (d *EngineerDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("email"),
+		),
+	}
+}
+
 func (d *EngineerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -78,7 +114,11 @@ func (d *EngineerDataSource) Configure(ctx context.Context, req datasource.Confi
 }
 
 func (d *EngineerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state EngineerModel
+	if !clientConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state EngineerDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
@@ -86,19 +126,56 @@ func (d *EngineerDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// resp.Diagnostics.AddWarning("foo bar", fmt.Sprintf("this is the data sources name: %s", state.Name))
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	engineer, err := d.client.GetEngineerByName(state.Name.ValueString())
+	// Look the engineer up by whichever attribute was configured
+	var engineer *devops_resource.Engineer
+	var err error
+	var lookup path.Path
+	switch {
+	case !state.Id.IsNull():
+		lookup = path.Root("id")
+		engineer, err = d.client.GetEngineer(ctx, state.Id.ValueString())
+	case !state.Email.IsNull():
+		lookup = path.Root("email")
+		engineer, err = d.client.FindEngineerByEmail(ctx, state.Email.ValueString())
+	default:
+		lookup = path.Root("name")
+		engineer, err = d.client.FindEngineerByName(ctx, state.Name.ValueString())
+	}
+
+	var ambiguous *AmbiguousError
+	if errors.As(err, &ambiguous) {
+		resp.Diagnostics.AddAttributeError(
+			lookup,
+			"Ambiguous Engineer Lookup",
+			fmt.Sprintf("The lookup matched more than one engineer, %s. Look the engineer up by id instead.", err),
+		)
+		return
+	}
+
+	if errors.Is(err, ErrNotFound) {
+		if state.FailIfNotFound.IsNull() || state.FailIfNotFound.ValueBool() {
+			resp.Diagnostics.AddAttributeError(lookup, "Engineer Not Found", fmt.Sprintf("Unable to find engineer: %s", err))
+			return
+		}
+
+		// Only the configured lookup attribute is set, everything else stays null
+		tflog.Debug(ctx, fmt.Sprintf("engineer not found, ignoring: %s", err))
+		state.Exists = types.BoolValue(false)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read engineer, got error: %s", err))
 		return
 	}
 
 	state.Email = types.StringValue(engineer.Email)
 	state.Id = types.StringValue(engineer.Id)
 	state.Name = types.StringValue(engineer.Name)
+	state.Exists = types.BoolValue(true)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -16,20 +17,54 @@ func TestAccEngineerDataSource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccEngineerDataSourceConfig,
+				Config: providerConfig + testAccEngineerDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "name", "Ryan"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "name", "tf-acc-Ryan"),
 					resource.TestCheckResourceAttrSet("data.devops-bootcamp_engineer.test", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "exists", "true"),
+				),
+			},
+			// Lookup by id and email
+			{
+				Config: providerConfig + testAccEngineerDataSourceLookupConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.by_id", "name", "tf-acc-Ryan"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.by_id", "email", "Ryan@gmail.com"),
+					resource.TestCheckResourceAttrPair("data.devops-bootcamp_engineer.by_email", "id", "devops-bootcamp_engineer.test", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.by_email", "name", "tf-acc-Ryan"),
+				),
+			},
+			// More than one lookup attribute
+			{
+				Config: providerConfig + `
+	data "devops-bootcamp_engineer" "test" {
+	  name  = "tf-acc-Ryan"
+	  email = "Ryan@gmail.com"
+	}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Missing engineer without failing
+			{
+				Config: providerConfig + `
+	data "devops-bootcamp_engineer" "missing" {
+	  name              = "NobodyByThisName"
+	  fail_if_not_found = false
+	}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.missing", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.devops-bootcamp_engineer.missing", "email"),
 				),
 			},
 		},
 	})
 }
 
-const testAccEngineerDataSourceConfig = providerConfig + `
+const testAccEngineerDataSourceConfig = `
 
 	resource "devops-bootcamp_engineer" "test" {
-		name  = "Ryan"
+		name  = "tf-acc-Ryan"
 		email = "Ryan@gmail.com"
 	}
 
@@ -38,3 +73,20 @@ const testAccEngineerDataSourceConfig = providerConfig + `
 	}
 
 `
+
+const testAccEngineerDataSourceLookupConfig = `
+
+	resource "devops-bootcamp_engineer" "test" {
+		name  = "tf-acc-Ryan"
+		email = "Ryan@gmail.com"
+	}
+
+	data "devops-bootcamp_engineer" "by_id" {
+	  id = devops-bootcamp_engineer.test.id
+	}
+
+	data "devops-bootcamp_engineer" "by_email" {
+	  email = lower(devops-bootcamp_engineer.test.email)
+	}
+
+`
@@ -1,6 +1,13 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
 
 // EngineerModel describes the data source data model.
 type EngineerModel struct {
@@ -9,3 +16,28 @@ type EngineerModel struct {
 	Email       types.String `tfsdk:"email"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
+
+// engineerAttrTypes is the object type of EngineerModel, used when engineers
+// are nested inside a computed attribute and need to be held in a types.List.
+var engineerAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"email":        types.StringType,
+	"last_updated": types.StringType,
+}
+
+// engineersListValue converts engineers returned by the API into a list value
+// of EngineerModel objects.
+func engineersListValue(ctx context.Context, engineers []*devops_resource.Engineer) (types.List, diag.Diagnostics) {
+	models := []EngineerModel{}
+	for _, engineer := range engineers {
+		models = append(models, EngineerModel{
+			Id:          types.StringValue(engineer.Id),
+			Name:        types.StringValue(engineer.Name),
+			Email:       types.StringValue(engineer.Email),
+			LastUpdated: types.StringNull(),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: engineerAttrTypes}, models)
+}
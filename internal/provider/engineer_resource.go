@@ -5,14 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
@@ -21,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EngineerResource{}
 var _ resource.ResourceWithImportState = &EngineerResource{}
+var _ resource.ResourceWithModifyPlan = &EngineerResource{}
 
 func NewEngineerResource() resource.Resource {
 	return &EngineerResource{}
@@ -31,6 +38,19 @@ type EngineerResource struct {
 	client *Client
 }
 
+// engineerNameRegexp restricts engineer names to characters the API can
+// safely use in the /engineers/name/{name} path.
+var engineerNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 .'_-]*$`)
+
+// EngineerResourceModel describes the resource data model.
+type EngineerResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Email       types.String   `tfsdk:"email"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
 func (r *EngineerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_engineer"
 }
@@ -42,12 +62,22 @@ func (r *EngineerResource) Schema(ctx context.Context, req resource.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the Engineer",
-				Optional:            true,
+				MarkdownDescription: "Name of the Engineer. Between 1 and 64 characters, starting with a letter or digit and otherwise limited to letters, digits, spaces and `.'_-`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+					stringvalidator.RegexMatches(
+						engineerNameRegexp,
+						"must start with a letter or digit and only contain letters, digits, spaces and .'_-",
+					),
+				},
 			},
 			"email": schema.StringAttribute{
-				MarkdownDescription: "Email of the Engineer",
+				MarkdownDescription: "Email of the Engineer. Must be in one of the provider's `allowed_email_domains` when those are configured.",
 				Optional:            true,
+				Validators: []validator.String{
+					emailValidator{},
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Id of the Engineer",
@@ -60,7 +90,64 @@ func (r *EngineerResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *EngineerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state *EngineerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	email := plan.Email
+	domains := r.client.AllowedEmailDomains
+	if len(domains) > 0 && !email.IsNull() && !email.IsUnknown() && !emailDomainAllowed(email.ValueString(), domains) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Email Domain Not Allowed",
+			fmt.Sprintf("The email %q is not in one of the domains allowed by the provider: %s.", email.ValueString(), strings.Join(domains, ", ")),
+		)
+	}
+
+	// Only creates and renames can introduce a duplicate name
+	if plan.Name.IsUnknown() || (state != nil && state.Name.Equal(plan.Name)) {
+		return
 	}
+
+	engineers, err := r.client.ListEngineers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check engineer name uniqueness, got error: %s", err))
+		return
+	}
+
+	var ids []string
+	for _, engineer := range engineers {
+		if engineer.Name == plan.Name.ValueString() && (state == nil || engineer.Id != state.Id.ValueString()) {
+			ids = append(ids, engineer.Id)
+		}
+	}
+
+	addNameConflict(&resp.Diagnostics, r.client.WarnOnNameConflict, "engineer", plan.Name.ValueString(), ids)
 }
 
 func (r *EngineerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -84,7 +171,11 @@ func (r *EngineerResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *EngineerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan *EngineerModel
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan *EngineerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -93,9 +184,24 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	engineer, err := r.client.CreateEngineer(plan.Name.ValueString(), plan.Email.ValueString())
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	start := time.Now()
+
+	engineer, err := r.client.CreateEngineer(ctx, plan.Name.ValueString(), plan.Email.ValueString())
 
 	if err != nil {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "creating engineer", createTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error creating engineer",
 			"Could not create engineer,unexpected error:"+err.Error(),
@@ -119,7 +225,11 @@ func (r *EngineerResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state *EngineerModel
+	if !clientRefreshable(ctx, r.client) {
+		return
+	}
+
+	var state *EngineerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -128,8 +238,30 @@ func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	engineer, err := r.client.GetEngineer(state.Id.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	start := time.Now()
+
+	engineer, err := r.client.GetEngineer(ctx, state.Id.ValueString())
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// The engineer was deleted outside of Terraform, remove it from state so it is recreated
+			tflog.Warn(ctx, fmt.Sprintf("engineer %s not found, removing from state", state.Id))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		if timeoutExceeded(ctx, &resp.Diagnostics, "reading engineer", readTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
 		return
 	}
@@ -142,7 +274,11 @@ func (r *EngineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan *EngineerModel
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan *EngineerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -151,10 +287,25 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	start := time.Now()
+
 	// Update via client api
-	body, err := r.client.UpdateEngineer(plan.Id.ValueString(), plan.Name.ValueString(), plan.Email.ValueString())
+	body, err := r.client.UpdateEngineer(ctx, plan.Id.ValueString(), plan.Name.ValueString(), plan.Email.ValueString())
 
 	if err != nil {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "updating engineer", updateTimeout, start) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error creating engineer",
 			"Could not create engineer,unexpected error:"+err.Error(),
@@ -172,7 +323,11 @@ func (r *EngineerResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *EngineerModel
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data *EngineerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -181,15 +336,31 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	start := time.Now()
+
 	engineer := devops_resource.Engineer{
 		Id:    data.Id.ValueString(),
 		Name:  data.Name.ValueString(),
 		Email: data.Email.ValueString(),
 	}
 
-	err := r.client.DeleteEngineer(&engineer)
+	err := r.client.DeleteEngineer(ctx, &engineer)
+
+	// Nothing left to delete if the engineer is already gone
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		if timeoutExceeded(ctx, &resp.Diagnostics, "deleting engineer", deleteTimeout, start) {
+			return
+		}
 
-	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Engineer",
 			"Could not delete engineer, unexpected error: "+err.Error(),
@@ -198,6 +369,30 @@ func (r *EngineerResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ImportState accepts an engineer id, "name:<name>" or "email:<email>".
 func (r *EngineerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	kind, value := parseImportId(req.ID, "name", "email")
+
+	var engineer *devops_resource.Engineer
+	var err error
+	switch kind {
+	case "name":
+		engineer, err = r.client.FindEngineerByName(ctx, value)
+	case "email":
+		engineer, err = r.client.FindEngineerByEmail(ctx, value)
+	default:
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if err != nil {
+		addImportLookupError(&resp.Diagnostics, "engineer", req.ID, err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), engineer.Id)...)
 }
@@ -1,26 +1,82 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+	"terraform-provider-devops-bootcamp/internal/bootcampapi"
 )
 
 func TestEngineerResource(t *testing.T) {
+	var engineerId string
+
+	updatedConfig := providerConfig + `
+resource "devops-bootcamp_engineer" "test" {
+	name  = "tf-acc-updatedBobby"
+	email = "updatedBobby@gmail.com"
+
+	timeouts {
+		create = "5m"
+		read   = "1m"
+		update = "5m"
+		delete = "2m"
+	}
+}
+`
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Plan time validation
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer" "test" {
+	name  = "tf-acc-Bobby"
+	email = "Bobby <Bobby@gmail.com>"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Email Address`),
+			},
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer" "test" {
+	name  = "Bobby/../admin"
+	email = "Bobby@gmail.com"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must start with a letter or digit`),
+			},
+			{
+				Config: testProviderConfig(`allowed_email_domains = ["liatrio.com"]`) + `
+resource "devops-bootcamp_engineer" "test" {
+	name  = "tf-acc-Bobby"
+	email = "Bobby@gmail.com"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Email Domain Not Allowed`),
+			},
 			// Create and Read testing
 			{
 				Config: providerConfig + `
 resource "devops-bootcamp_engineer" "test" {
-	name  = "Bobby"
+	name  = "tf-acc-Bobby"
 	email = "Bobby@gmail.com"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify name
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "Bobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "tf-acc-Bobby"),
 					// Verify email
 					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "Bobby@gmail.com"),
 					// Verify dynamic values have any value set in the state.
@@ -31,18 +87,216 @@ resource "devops-bootcamp_engineer" "test" {
 
 				Config: providerConfig + `
 resource "devops-bootcamp_engineer" "test" {
-	name  = "updatedBobby"
+	name  = "tf-acc-updatedBobby"
 	email = "updatedBobby@gmail.com"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify name/email updated
-					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "tf-acc-updatedBobby"),
 					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "updatedBobby@gmail.com"),
 					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer.test", "id"),
+					testAccCaptureId("devops-bootcamp_engineer.test", &engineerId),
+				),
+			},
+			// Operation timeouts
+			{
+
+				Config: updatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "timeouts.read", "1m"),
+				),
+			},
+			// Import by name and by email
+			{
+				ResourceName:            "devops-bootcamp_engineer.test",
+				ImportState:             true,
+				ImportStateId:           "name:tf-acc-updatedBobby",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "timeouts"},
+			},
+			{
+				ResourceName:            "devops-bootcamp_engineer.test",
+				ImportState:             true,
+				ImportStateId:           "email:updatedBobby@gmail.com",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "timeouts"},
+			},
+			// Import by id
+			{
+				ResourceName:            "devops-bootcamp_engineer.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "timeouts"},
+			},
+			// Changes made outside of Terraform are detected and reverted
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					_, err := client.UpdateEngineer(ctx, engineerId, "tf-acc-updatedBobby", "driftedBobby@gmail.com")
+					return err
+				}),
+				Config:             updatedConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_engineer.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "email", "updatedBobby@gmail.com"),
+					testAccCheckAPI(func(ctx context.Context, client *Client) error {
+						engineer, err := client.GetEngineer(ctx, engineerId)
+						if err != nil {
+							return err
+						}
+						if engineer.Email != "updatedBobby@gmail.com" {
+							return fmt.Errorf("expected the email to be reverted, got %s", engineer.Email)
+						}
+						return nil
+					}),
+				),
+			},
+			// An engineer deleted outside of Terraform is planned to be recreated
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					return client.DeleteEngineer(ctx, &devops_resource.Engineer{Id: engineerId})
+				}),
+				Config:             updatedConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_engineer.test", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttrWith("devops-bootcamp_engineer.test", "id", func(id string) error {
+						if id == engineerId {
+							return fmt.Errorf("expected a new engineer, got the deleted id %s", id)
+						}
+						return nil
+					}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestEngineerResourceNameConflict(t *testing.T) {
+	existing := `
+resource "devops-bootcamp_engineer" "existing" {
+	name  = "tf-acc-BobbyTwin"
+	email = "bobbyTwin@gmail.com"
+}
+`
+	duplicate := `
+resource "devops-bootcamp_engineer" "duplicate" {
+	name  = "tf-acc-BobbyTwin"
+	email = "bobbyTwin2@gmail.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + existing,
+			},
+			// A second engineer with the same name fails the plan
+			{
+				Config:      providerConfig + existing + duplicate,
+				ExpectError: regexp.MustCompile(`Duplicate Engineer Name`),
+			},
+			// Unless the provider only warns about it
+			{
+				Config: testProviderConfig(`name_conflict_severity = "warning"`) + existing + duplicate,
+				Check:  resource.TestCheckResourceAttr("devops-bootcamp_engineer.duplicate", "name", "tf-acc-BobbyTwin"),
+			},
+		},
+	})
+}
+
+func TestEngineerResourceFaults(t *testing.T) {
+	config := func(name string, timeouts string) string {
+		return testProviderConfig(`max_retries = 0`) + fmt.Sprintf(`
+resource "devops-bootcamp_engineer" "test" {
+	name  = %q
+	email = "faulty@gmail.com"
+	%s
+}
+`, name, timeouts)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckInMemoryAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The truncated create went through on the API, leaving an engineer
+		// Terraform never stored and so never destroyed
+		CheckDestroy: testAccCheckAPI(func(ctx context.Context, client *Client) error {
+			engineer, err := client.FindEngineerByName(ctx, "tf-acc-TruncatedBobby")
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			return client.DeleteEngineer(ctx, engineer)
+		}),
+		Steps: []resource.TestStep{
+			// Error status
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPost, "/engineers", bootcampapi.Fault{Status: http.StatusInternalServerError, Body: "boom", Times: 1})
+				},
+				Config:      config("tf-acc-FaultyBobby", ""),
+				ExpectError: regexp.MustCompile(`(?s)Error creating engineer.*status: 500, body: boom`),
+			},
+			// Malformed response
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPost, "/engineers", bootcampapi.Fault{Truncate: true, Times: 1})
+				},
+				Config:      config("tf-acc-TruncatedBobby", ""),
+				ExpectError: regexp.MustCompile(`(?s)Error creating engineer.*unexpected end of JSON input`),
+			},
+			// Slow response
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPost, "/engineers", bootcampapi.Fault{Latency: 5 * time.Second, Times: 1})
+				},
+				Config:      config("tf-acc-SlowBobby", `timeouts { create = "1s" }`),
+				ExpectError: regexp.MustCompile(`Timeout creating engineer`),
+			},
+			// Dropped connection
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodPost, "/engineers", bootcampapi.Fault{Reset: true, Times: 1})
+				},
+				Config:      config("tf-acc-ResetBobby", ""),
+				ExpectError: regexp.MustCompile(`(?s)Error creating engineer.*Could not create engineer.*(connection reset by peer|EOF)`),
+			},
+			// Failed refresh of an existing engineer
+			{
+				Config: config("tf-acc-FaultyBobby", ""),
+			},
+			{
+				PreConfig: func() {
+					testAccInjectFault(t, http.MethodGet, "/engineers/id/", bootcampapi.Fault{Status: http.StatusServiceUnavailable, Body: "maintenance", Times: 1})
+				},
+				Config:      config("tf-acc-FaultyBobby", ""),
+				ExpectError: regexp.MustCompile(`(?s)Client Error.*status: 503, body: maintenance`),
+			},
+		},
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &EngineersDataSource{}
+	_ datasource.DataSourceWithConfigure = &EngineersDataSource{}
+)
+
+func NewEngineersDataSource() datasource.DataSource {
+	return &EngineersDataSource{}
+}
+
+// EngineersDataSource defines the data source implementation.
+type EngineersDataSource struct {
+	client *Client
+}
+
+// EngineersModel describes the data source data model.
+type EngineersModel struct {
+	Id          types.String      `tfsdk:"id"`
+	NameRegex   types.String      `tfsdk:"name_regex"`
+	EmailDomain types.String      `tfsdk:"email_domain"`
+	DevId       types.String      `tfsdk:"dev_id"`
+	OpsId       types.String      `tfsdk:"ops_id"`
+	Engineers   []EngineerModel   `tfsdk:"engineers"`
+	Emails      map[string]string `tfsdk:"emails"`
+	Names       map[string]string `tfsdk:"names"`
+}
+
+func (d *EngineersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_engineers"
+}
+
+func (d *EngineersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists all engineers, optionally filtered",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source identifier",
+				Computed:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return engineers whose name matches this regular expression",
+				Optional:            true,
+			},
+			"email_domain": schema.StringAttribute{
+				MarkdownDescription: "Only return engineers whose email address is in this domain",
+				Optional:            true,
+			},
+			"dev_id": schema.StringAttribute{
+				MarkdownDescription: "Only return engineers that are members of the developer group with this id",
+				Optional:            true,
+			},
+			"ops_id": schema.StringAttribute{
+				MarkdownDescription: "Only return engineers that are members of the operations group with this id",
+				Optional:            true,
+			},
+			"engineers": schema.ListNestedAttribute{
+				MarkdownDescription: "List of engineers matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_updated": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"emails": schema.MapAttribute{
+				MarkdownDescription: "Map of engineer id to email of the engineers matching the filters",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"names": schema.MapAttribute{
+				MarkdownDescription: "Map of engineer id to name of the engineers matching the filters",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EngineersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EngineersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !clientConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state EngineersModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				fmt.Sprintf("Unable to compile name_regex: %s", err),
+			)
+			return
+		}
+	}
+
+	// Collect the members of the groups to filter by
+	var devMembers, opsMembers map[string]bool
+	if !state.DevId.IsNull() {
+		dev, err := d.client.GetDevById(ctx, state.DevId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dev %s, got error: %s", state.DevId.ValueString(), err))
+			return
+		}
+		devMembers = engineerIds(dev.Engineers)
+	}
+
+	if !state.OpsId.IsNull() {
+		ops, err := d.client.GetOpsById(ctx, state.OpsId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ops %s, got error: %s", state.OpsId.ValueString(), err))
+			return
+		}
+		opsMembers = engineerIds(ops.Engineers)
+	}
+
+	engineers, err := d.client.ListEngineers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list engineers, got error: %s", err))
+		return
+	}
+
+	emailDomain := strings.ToLower(strings.TrimPrefix(state.EmailDomain.ValueString(), "@"))
+
+	state.Engineers = []EngineerModel{}
+	state.Emails = map[string]string{}
+	state.Names = map[string]string{}
+	for _, engineer := range engineers {
+		if nameRegex != nil && !nameRegex.MatchString(engineer.Name) {
+			continue
+		}
+		if emailDomain != "" && !strings.HasSuffix(strings.ToLower(engineer.Email), "@"+emailDomain) {
+			continue
+		}
+		if devMembers != nil && !devMembers[engineer.Id] {
+			continue
+		}
+		if opsMembers != nil && !opsMembers[engineer.Id] {
+			continue
+		}
+
+		state.Engineers = append(state.Engineers, EngineerModel{
+			Id:    types.StringValue(engineer.Id),
+			Name:  types.StringValue(engineer.Name),
+			Email: types.StringValue(engineer.Email),
+		})
+		state.Emails[engineer.Id] = engineer.Email
+		state.Names[engineer.Id] = engineer.Name
+	}
+
+	state.Id = types.StringValue("engineers")
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read %d engineers", len(state.Engineers)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// engineerIds returns the set of ids of engineers.
+func engineerIds(engineers []*devops_resource.Engineer) map[string]bool {
+	ids := map[string]bool{}
+	for _, engineer := range engineers {
+		ids[engineer.Id] = true
+	}
+
+	return ids
+}
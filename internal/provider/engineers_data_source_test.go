@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEngineersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccEngineersDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineers.by_domain", "engineers.#", "2"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineers.by_name", "engineers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.devops-bootcamp_engineers.by_name", "engineers.0.id", "devops-bootcamp_engineer.ryan", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineers.by_dev", "engineers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.devops-bootcamp_engineers.by_dev", "engineers.0.id", "devops-bootcamp_engineer.ava", "id"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineers.by_dev", "emails.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEngineersDataSourceConfig = `
+
+	resource "devops-bootcamp_engineer" "ryan" {
+		name  = "tf-acc-RyanRoster"
+		email = "ryan@roster.example.com"
+	}
+
+	resource "devops-bootcamp_engineer" "ava" {
+		name  = "tf-acc-AvaRoster"
+		email = "ava@roster.example.com"
+	}
+
+	resource "devops-bootcamp_dev" "tf-acc-roster" {
+		name      = "tf-acc-roster"
+		engineers = [ {id = devops-bootcamp_engineer.ava.id} ]
+	}
+
+	data "devops-bootcamp_engineers" "by_domain" {
+		email_domain = "roster.example.com"
+		depends_on   = [devops-bootcamp_engineer.ryan, devops-bootcamp_engineer.ava]
+	}
+
+	data "devops-bootcamp_engineers" "by_name" {
+		name_regex   = "^tf-acc-Ryan"
+		email_domain = "roster.example.com"
+		depends_on   = [devops-bootcamp_engineer.ryan, devops-bootcamp_engineer.ava]
+	}
+
+	data "devops-bootcamp_engineers" "by_dev" {
+		dev_id = devops-bootcamp_dev.roster.id
+	}
+
+`
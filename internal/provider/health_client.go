@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// supportedAPIMajorVersion is the major version of the bootcamp API this
+// provider is written against.
+const supportedAPIMajorVersion = 1
+
+// healthCheckTimeout bounds the whole health check, which reports an
+// unreachable API rather than waiting on it.
+const healthCheckTimeout = 10 * time.Second
+
+type apiVersion struct {
+	Version string `json:"version"`
+}
+
+// CheckHealth verifies the API is reachable and compatible, and records the
+// version it reports in c.APIVersion. APIs that predate the version endpoint
+// are probed through the engineers endpoint and leave APIVersion empty. The
+// check is not retried, so a down API fails provider configuration promptly
+// instead of after the whole backoff of c.Retry.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	probe := *c
+	probe.Retry.MaxRetries = 0
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/version", c.HostURL), nil)
+
+	if err != nil {
+		return err
+	}
+
+	body, err := probe.DoRequest(req)
+
+	if errors.Is(err, ErrNotFound) {
+		req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/engineers", c.HostURL), nil)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = probe.DoRequest(req)
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	version := apiVersion{}
+
+	err = json.Unmarshal(body, &version)
+
+	if err != nil {
+		return fmt.Errorf("unexpected version response: %w", err)
+	}
+
+	major, _, err := parseAPIVersion(version.Version)
+
+	if err != nil {
+		return err
+	}
+
+	if major != supportedAPIMajorVersion {
+		return fmt.Errorf("API version %s is not supported, this provider requires version %d.x", version.Version, supportedAPIMajorVersion)
+	}
+
+	c.APIVersion = version.Version
+
+	return nil
+}
+
+// APIVersionAtLeast reports whether the API detected by CheckHealth is at
+// least major.minor. It is false when the version is unknown.
+func (c *Client) APIVersionAtLeast(major int, minor int) bool {
+	gotMajor, gotMinor, err := parseAPIVersion(c.APIVersion)
+
+	if err != nil {
+		return false
+	}
+
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// parseAPIVersion returns the major and minor parts of a version such as
+// "1.2.3" or "v1.2".
+func parseAPIVersion(version string) (int, int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid API version %q", version)
+	}
+
+	minor := 0
+	if len(parts) > 1 {
+		minor, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid API version %q", version)
+		}
+	}
+
+	return major, minor, nil
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientCheckHealth(t *testing.T) {
+	cases := map[string]struct {
+		handler     http.HandlerFunc
+		wantErr     string
+		wantVersion string
+	}{
+		"compatible version": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version":"1.4.0"}`))
+			},
+			wantVersion: "1.4.0",
+		},
+		"incompatible version": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version":"2.0.0"}`))
+			},
+			wantErr: "not supported",
+		},
+		"no version endpoint": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/version" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			},
+		},
+		"unauthorized": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantErr: "status: 401",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			client := testRetryClient(server.URL)
+			err := client.CheckHealth(context.Background())
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if client.APIVersion != tc.wantVersion {
+				t.Errorf("expected API version %q, got %q", tc.wantVersion, client.APIVersion)
+			}
+		})
+	}
+}
+
+func TestClientCheckHealthDoesNotRetry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(server.URL)
+
+	if err := client.CheckHealth(context.Background()); err == nil {
+		t.Fatal("expected an error from an unavailable API")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+	if client.Retry.MaxRetries != 3 {
+		t.Errorf("expected the client to keep its retries, got %d", client.Retry.MaxRetries)
+	}
+}
+
+func TestClientAPIVersionAtLeast(t *testing.T) {
+	client := &Client{APIVersion: "v1.3.2"}
+
+	if !client.APIVersionAtLeast(1, 3) {
+		t.Error("expected 1.3.2 to be at least 1.3")
+	}
+	if client.APIVersionAtLeast(1, 4) {
+		t.Error("expected 1.3.2 not to be at least 1.4")
+	}
+	if (&Client{}).APIVersionAtLeast(1, 0) {
+		t.Error("expected an unknown version not to satisfy any minimum")
+	}
+}
+
+func TestHealthClient(t *testing.T) {
+	client := cassetteClient(t)
+
+	if err := client.CheckHealth(context.Background()); err != nil {
+		t.Fatalf("CheckHealth: %s", err)
+	}
+
+	if !client.APIVersionAtLeast(1, 0) {
+		t.Errorf("expected API version 1.0 or later, got %q", client.APIVersion)
+	}
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// parseImportId splits an import identifier such as "name:Ryan" into its
+// lookup kind and value. Identifiers without a known prefix are plain ids.
+func parseImportId(id string, kinds ...string) (string, string) {
+	kind, value, found := strings.Cut(id, ":")
+
+	if !found {
+		return "", id
+	}
+
+	for _, k := range kinds {
+		if kind == k {
+			return kind, value
+		}
+	}
+
+	return "", id
+}
+
+// addImportLookupError adds a diagnostic explaining why the object an import
+// identifier refers to could not be resolved.
+func addImportLookupError(diags *diag.Diagnostics, object string, id string, err error) {
+	var ambiguous *AmbiguousError
+
+	switch {
+	case errors.As(err, &ambiguous):
+		diags.AddError(
+			"Ambiguous Import Identifier",
+			fmt.Sprintf("The import identifier %q matched more than one %s, %s. Import the %s by id instead.", id, object, err, object),
+		)
+	case errors.Is(err, ErrNotFound):
+		diags.AddError(
+			"Cannot Import Non-Existent Object",
+			fmt.Sprintf("No %s matches the import identifier %q.", object, id),
+		)
+	default:
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to look up %s for import identifier %q, got error: %s", object, id, err),
+		)
+	}
+}
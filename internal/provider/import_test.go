@@ -0,0 +1,26 @@
+package provider
+
+import "testing"
+
+func TestParseImportId(t *testing.T) {
+	cases := map[string]struct {
+		id        string
+		wantKind  string
+		wantValue string
+	}{
+		"plain id":       {id: "64b0c9", wantKind: "", wantValue: "64b0c9"},
+		"name":           {id: "name:Ryan", wantKind: "name", wantValue: "Ryan"},
+		"email":          {id: "email:ryan@example.com", wantKind: "email", wantValue: "ryan@example.com"},
+		"colon in value": {id: "name:Ryan: the second", wantKind: "name", wantValue: "Ryan: the second"},
+		"unknown prefix": {id: "team:backend", wantKind: "", wantValue: "team:backend"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kind, value := parseImportId(tc.id, "name", "email")
+			if kind != tc.wantKind || value != tc.wantValue {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tc.wantKind, tc.wantValue, kind, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Function to create an ops
+func (c *Client) CreateOps(ctx context.Context, ops *devops_resource.Ops) (*devops_resource.Ops, error) {
+	reqBody, err := json.Marshal(ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/op", c.HostURL), bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newOps := devops_resource.Ops{}
+
+	err = json.Unmarshal(res, &newOps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newOps, nil
+}
+
+func (c *Client) GetOpsByName(ctx context.Context, name string) (*devops_resource.Ops, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/op/name/%s", c.HostURL, name), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ops := devops_resource.Ops{}
+
+	err = json.Unmarshal(body, &ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ops, nil
+}
+
+func (c *Client) GetOpsById(ctx context.Context, id string) (*devops_resource.Ops, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/op/id/%s", c.HostURL, id), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ops := devops_resource.Ops{}
+
+	err = json.Unmarshal(body, &ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ops, nil
+}
+
+func (c *Client) DeleteOps(ctx context.Context, ops *devops_resource.Ops) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/op/%s", c.HostURL, ops.Id), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DoRequest(req)
+
+	return err
+}
+
+func (c *Client) UpdateOps(ctx context.Context, ops *devops_resource.Ops) (*devops_resource.Ops, error) {
+	reqBody, err := json.Marshal(ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/op/%s", c.HostURL, ops.Id), bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newOps := devops_resource.Ops{}
+
+	err = json.Unmarshal(res, &newOps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newOps, nil
+}
+
+func (c *Client) ListOps(ctx context.Context) ([]*devops_resource.Ops, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/op", c.HostURL), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.DoRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []*devops_resource.Ops{}
+
+	err = json.Unmarshal(body, &ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestOpsClient(t *testing.T) {
+	client := cassetteClient(t)
+	ctx := context.Background()
+
+	engineer, err := client.CreateEngineer(ctx, "tf-acc-CassetteOpsEngineer", "ops@cassette.com")
+	if err != nil {
+		t.Fatalf("CreateEngineer: %s", err)
+	}
+
+	created, err := client.CreateOps(ctx, &devops_resource.Ops{
+		Name:      "tf-acc-CassetteOps",
+		Engineers: []*devops_resource.Engineer{{Id: engineer.Id}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOps: %s", err)
+	}
+	if created.Id == "" || created.Name != "tf-acc-CassetteOps" || len(created.Engineers) != 1 || *created.Engineers[0] != *engineer {
+		t.Fatalf("CreateOps: unexpected ops %+v", created)
+	}
+
+	ops, err := client.GetOpsById(ctx, created.Id)
+	if err != nil || ops.Name != "tf-acc-CassetteOps" || len(ops.Engineers) != 1 {
+		t.Fatalf("GetOpsById: unexpected ops %+v, %v", ops, err)
+	}
+
+	ops, err = client.GetOpsByName(ctx, "tf-acc-CassetteOps")
+	if err != nil || ops.Id != created.Id {
+		t.Fatalf("GetOpsByName: expected %s, got %+v, %v", created.Id, ops, err)
+	}
+
+	updated, err := client.UpdateOps(ctx, &devops_resource.Ops{
+		Id:        created.Id,
+		Name:      "tf-acc-RenamedCassetteOps",
+		Engineers: []*devops_resource.Engineer{},
+	})
+	if err != nil || updated.Name != "tf-acc-RenamedCassetteOps" || len(updated.Engineers) != 0 {
+		t.Fatalf("UpdateOps: unexpected ops %+v, %v", updated, err)
+	}
+
+	all, err := client.ListOps(ctx)
+	if err != nil || findById(all, created.Id, func(ops *devops_resource.Ops) string { return ops.Id }) == nil {
+		t.Fatalf("ListOps: expected %s among %v, %v", created.Id, all, err)
+	}
+
+	if err := client.DeleteOps(ctx, created); err != nil {
+		t.Fatalf("DeleteOps: %s", err)
+	}
+
+	_, err = client.GetOpsById(ctx, created.Id)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetOpsById: expected ErrNotFound after delete, got %v", err)
+	}
+
+	if err := client.DeleteEngineer(ctx, engineer); err != nil {
+		t.Fatalf("DeleteEngineer: %s", err)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OpsResource{}
+var _ resource.ResourceWithImportState = &OpsResource{}
+
+func NewOpsResource() resource.Resource {
+	return &OpsResource{}
+}
+
+// OpsResource defines the resource implementation.
+type OpsResource struct {
+	client *Client
+}
+
+// OpsResourceModel describes the resource data model.
+type OpsResourceModel struct {
+	Id          types.String    `tfsdk:"id"`
+	Name        types.String    `tfsdk:"name"`
+	Engineers   []EngineerModel `tfsdk:"engineers"`
+	LastUpdated types.String    `tfsdk:"last_updated"`
+}
+
+func (r *OpsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ops"
+}
+
+func (r *OpsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Operations group resource",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the operations group",
+				Required:            true,
+			},
+			"engineers": schema.ListNestedAttribute{
+				MarkdownDescription: "List of engineers in the operations group by id",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Optional: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_updated": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Ops identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *OpsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OpsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var planned *OpsResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	var reqObj devops_resource.Ops
+	reqObj.Name = planned.Name.ValueString()
+	for _, engineer := range planned.Engineers {
+		reqObj.Engineers = append(reqObj.Engineers, &devops_resource.Engineer{
+			Id: engineer.Id.ValueString(),
+		})
+	}
+
+	// Make empty list if no engineers are provided
+	if reqObj.Engineers == nil {
+		reqObj.Engineers = make([]*devops_resource.Engineer, 0)
+	}
+
+	ops, err := r.client.CreateOps(ctx, &reqObj)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ops",
+			"Could not create ops,unexpected error:"+err.Error(),
+		)
+		return
+	}
+
+	// Map the response to the planned model
+	planned.Id = types.StringValue(ops.Id)
+	planned.Name = types.StringValue(ops.Name)
+	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	planned.Engineers = []EngineerModel{}
+
+	for _, engineer := range ops.Engineers {
+		planned.Engineers = append(planned.Engineers, EngineerModel{
+			Id:    types.StringValue(engineer.Id),
+			Name:  types.StringValue(engineer.Name),
+			Email: types.StringValue(engineer.Email),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created an ops resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planned)...)
+}
+
+func (r *OpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !clientRefreshable(ctx, r.client) {
+		return
+	}
+
+	var state *OpsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ops, err := r.client.GetOpsById(ctx, state.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// The ops was deleted outside of Terraform, remove it from state so it is recreated
+			tflog.Warn(ctx, fmt.Sprintf("ops %s not found, removing from state", state.Id))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ops, got error: %s", err))
+		return
+	}
+
+	state.Id = types.StringValue(ops.Id)
+	state.Name = types.StringValue(ops.Name)
+
+	state.Engineers = []EngineerModel{}
+	for _, engineer := range ops.Engineers {
+		state.Engineers = append(state.Engineers, EngineerModel{
+			Id:    types.StringValue(engineer.Id),
+			Name:  types.StringValue(engineer.Name),
+			Email: types.StringValue(engineer.Email),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("read resource for id:%s", state.Id))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *OpsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var planned *OpsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	var reqObj devops_resource.Ops
+	reqObj.Id = planned.Id.ValueString()
+	reqObj.Name = planned.Name.ValueString()
+	for _, engineer := range planned.Engineers {
+		reqObj.Engineers = append(reqObj.Engineers, &devops_resource.Engineer{
+			Id:    engineer.Id.ValueString(),
+			Name:  engineer.Name.ValueString(),
+			Email: engineer.Email.ValueString(),
+		})
+	}
+
+	// update the ops
+	_, err := r.client.UpdateOps(ctx, &reqObj)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ops",
+			"Could not update ops,unexpected error:"+err.Error(),
+		)
+		return
+	}
+
+	// Fetch the updated ops from the API
+	ops, err := r.client.GetOpsById(ctx, planned.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ops, got error: %s", err))
+		return
+	}
+
+	// Update the planned model with the updated ops
+	planned.Id = types.StringValue(ops.Id)
+	planned.Name = types.StringValue(ops.Name)
+	planned.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	planned.Engineers = []EngineerModel{}
+	for _, engineer := range ops.Engineers {
+		planned.Engineers = append(planned.Engineers, EngineerModel{
+			Id:    types.StringValue(engineer.Id),
+			Name:  types.StringValue(engineer.Name),
+			Email: types.StringValue(engineer.Email),
+		})
+	}
+
+	tflog.Trace(ctx, "updated an ops resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planned)...)
+}
+
+func (r *OpsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !clientConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state *OpsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ops := devops_resource.Ops{
+		Id:   state.Id.ValueString(),
+		Name: state.Name.ValueString(),
+	}
+
+	err := r.client.DeleteOps(ctx, &ops)
+
+	// Nothing left to delete if the ops is already gone
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Ops",
+			"Could not delete ops, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *OpsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+func TestOpsResource(t *testing.T) {
+	var opsId, engineerId string
+
+	noEngineersConfig := providerConfig + `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-Bobby"
+	email = "bobby@bobby.com"
+}
+resource "devops-bootcamp_ops" "test" {
+	name  = "tf-acc-updatedBobby"
+	engineers = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_ops" "test" {
+	name  = "tf-acc-Bobby"
+	engineers = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify name
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "name", "tf-acc-Bobby"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("devops-bootcamp_ops.test", "id")),
+			},
+			// Update and Read testing
+			{
+
+				Config: providerConfig + `
+resource "devops-bootcamp_ops" "test" {
+	name  = "tf-acc-updatedBobby"
+	engineers = []
+}
+			`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_ops.test", "id"),
+				),
+			},
+			// Add a new engineer
+			{
+
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer" "test_engineer" {
+	name  = "tf-acc-Bobby"
+	email = "bobby@bobby.com"
+}
+resource "devops-bootcamp_ops" "test" {
+	name  = "tf-acc-updatedBobby"
+	engineers = [ {id = devops-bootcamp_engineer.test_engineer.id} ]
+}
+			`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_ops.test", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "engineers.#", "1"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_ops.test", "engineers.0.id", "devops-bootcamp_engineer.test_engineer", "id"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_ops.test", "engineers.0.name", "devops-bootcamp_engineer.test_engineer", "name"),
+				),
+			},
+			// Remove the engineer again
+			{
+
+				Config: noEngineersConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "engineers.#", "0"),
+					testAccCaptureId("devops-bootcamp_ops.test", &opsId),
+					testAccCaptureId("devops-bootcamp_engineer.test_engineer", &engineerId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "devops-bootcamp_ops.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Renaming the ops and adding an engineer outside of Terraform is
+			// detected and reverted
+			{
+				PreConfig: testAccDrift(t, func(ctx context.Context, client *Client) error {
+					_, err := client.UpdateOps(ctx, &devops_resource.Ops{
+						Id:        opsId,
+						Name:      "tf-acc-updatedBobbyDrifted",
+						Engineers: []*devops_resource.Engineer{{Id: engineerId}},
+					})
+					return err
+				}),
+				Config:             noEngineersConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("devops-bootcamp_ops.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: noEngineersConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "name", "tf-acc-updatedBobby"),
+					resource.TestCheckResourceAttr("devops-bootcamp_ops.test", "engineers.#", "0"),
+					testAccCheckAPI(func(ctx context.Context, client *Client) error {
+						ops, err := client.GetOpsById(ctx, opsId)
+						if err != nil {
+							return err
+						}
+						if ops.Name != "tf-acc-updatedBobby" || len(ops.Engineers) != 0 {
+							return fmt.Errorf("expected the ops to be reverted, got %s with %d engineers", ops.Name, len(ops.Engineers))
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
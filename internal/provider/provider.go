@@ -5,12 +5,30 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of the name_conflict_severity provider attribute.
+const (
+	nameConflictError   = "error"
+	nameConflictWarning = "warning"
 )
 
 // Ensure DevOpsBootcampProvider satisfies various provider interfaces.
@@ -26,7 +44,18 @@ type DevOpsBootcampProvider struct {
 
 // DevOpsBootcampProviderModel describes the provider data model.
 type DevOpsBootcampProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint             types.String `tfsdk:"endpoint"`
+	MaxRetries           types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait         types.Int64  `tfsdk:"retry_max_wait"`
+	RequestTimeout       types.Int64  `tfsdk:"request_timeout"`
+	Token                types.String `tfsdk:"token"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	APIKey               types.String `tfsdk:"api_key"`
+	APIKeyHeader         types.String `tfsdk:"api_key_header"`
+	SkipHealthCheck      types.Bool   `tfsdk:"skip_health_check"`
+	AllowedEmailDomains  types.List   `tfsdk:"allowed_email_domains"`
+	NameConflictSeverity types.String `tfsdk:"name_conflict_severity"`
 }
 
 func (p *DevOpsBootcampProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,7 +67,59 @@ func (p *DevOpsBootcampProvider) Schema(ctx context.Context, req provider.Schema
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Example provider attribute",
+				MarkdownDescription: "URL of the bootcamp API. May also be set with the `DEVOPS_BOOTCAMP_ENDPOINT` environment variable. Defaults to `http://localhost:8080`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times a request is retried after a connection error, `429` or `5xx` response. May also be set with the `DEVOPS_BOOTCAMP_MAX_RETRIES` environment variable. Defaults to `3`, `0` disables retrying.",
+				Optional:            true,
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait between retries, including waits requested by `Retry-After`. May also be set with the `DEVOPS_BOOTCAMP_RETRY_MAX_WAIT` environment variable. Defaults to `30`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds a single request to the API may take before it is aborted. May also be set with the `DEVOPS_BOOTCAMP_REQUEST_TIMEOUT` environment variable. Defaults to `60`.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token sent in the `Authorization` header. May also be set with the `DEVOPS_BOOTCAMP_TOKEN` environment variable. Conflicts with `username` and `password`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username for HTTP basic authentication. May also be set with the `DEVOPS_BOOTCAMP_USERNAME` environment variable.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password for HTTP basic authentication. May also be set with the `DEVOPS_BOOTCAMP_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key sent in the `api_key_header` header. May also be set with the `DEVOPS_BOOTCAMP_API_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key_header": schema.StringAttribute{
+				MarkdownDescription: "Name of the header the API key is sent in. May also be set with the `DEVOPS_BOOTCAMP_API_KEY_HEADER` environment variable. Defaults to `X-API-Key`.",
+				Optional:            true,
+			},
+			"allowed_email_domains": schema.ListAttribute{
+				MarkdownDescription: "Domains engineer emails must belong to, checked during plan. May also be set as a comma separated list with the `DEVOPS_BOOTCAMP_ALLOWED_EMAIL_DOMAINS` environment variable. Any domain is allowed when unset.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"name_conflict_severity": schema.StringAttribute{
+				MarkdownDescription: "Whether planning an engineer or developer group with the same name as one not managed by that resource is an `error` or a `warning`. " +
+					"May also be set with the `DEVOPS_BOOTCAMP_NAME_CONFLICT_SEVERITY` environment variable. Defaults to `error`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(nameConflictError, nameConflictWarning),
+				},
+			},
+			"skip_health_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip verifying that the API is reachable and compatible when the provider is configured. May also be set with the `DEVOPS_BOOTCAMP_SKIP_HEALTH_CHECK` environment variable. Defaults to `false`.",
 				Optional:            true,
 			},
 		},
@@ -54,14 +135,123 @@ func (p *DevOpsBootcampProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
+	// Attributes configured from other resources' outputs are unknown until
+	// apply. Leave the provider unconfigured for now, Terraform configures it
+	// again once the values are known.
+	if hasUnknownValue(data) {
+		tflog.Debug(ctx, "provider configuration contains unknown values, deferring client configuration")
+		return
+	}
+
 	// Configuration values are now available.
-	var host string
-	if !data.Endpoint.IsNull() {
-		host = data.Endpoint.ValueString()
+	host := stringValueOrEnv(data.Endpoint, "DEVOPS_BOOTCAMP_ENDPOINT")
+	if host == "" {
+		tflog.Info(ctx, fmt.Sprintf("endpoint not configured, using default %s", HOST_URL))
+		host = HOST_URL
+	}
+
+	endpoint, err := parseEndpoint(host)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid Endpoint",
+			fmt.Sprintf("The endpoint %q is not a valid API URL: %s. "+
+				"Set the endpoint attribute or the DEVOPS_BOOTCAMP_ENDPOINT environment variable to a URL such as %s.", host, err, HOST_URL),
+		)
+	}
+
+	retry := DefaultRetryConfig
+
+	if maxRetries, ok := int64ValueOrEnv(data.MaxRetries, "DEVOPS_BOOTCAMP_MAX_RETRIES", path.Root("max_retries"), &resp.Diagnostics); ok {
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				"max_retries must not be negative.",
+			)
+		}
+		retry.MaxRetries = int(maxRetries)
+	}
+
+	if retryMaxWait, ok := int64ValueOrEnv(data.RetryMaxWait, "DEVOPS_BOOTCAMP_RETRY_MAX_WAIT", path.Root("retry_max_wait"), &resp.Diagnostics); ok {
+		if retryMaxWait < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Max Wait",
+				"retry_max_wait must be at least 1 second.",
+			)
+		}
+		retry.MaxDelay = time.Duration(retryMaxWait) * time.Second
+		if retry.BaseDelay > retry.MaxDelay {
+			retry.BaseDelay = retry.MaxDelay
+		}
+	}
+
+	requestTimeout := DefaultRequestTimeout
+
+	if timeout, ok := int64ValueOrEnv(data.RequestTimeout, "DEVOPS_BOOTCAMP_REQUEST_TIMEOUT", path.Root("request_timeout"), &resp.Diagnostics); ok {
+		if timeout < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				"request_timeout must be at least 1 second.",
+			)
+		}
+		requestTimeout = time.Duration(timeout) * time.Second
+	}
+
+	auth := AuthConfig{
+		Token:        stringValueOrEnv(data.Token, "DEVOPS_BOOTCAMP_TOKEN"),
+		Username:     stringValueOrEnv(data.Username, "DEVOPS_BOOTCAMP_USERNAME"),
+		Password:     stringValueOrEnv(data.Password, "DEVOPS_BOOTCAMP_PASSWORD"),
+		APIKey:       stringValueOrEnv(data.APIKey, "DEVOPS_BOOTCAMP_API_KEY"),
+		APIKeyHeader: stringValueOrEnv(data.APIKeyHeader, "DEVOPS_BOOTCAMP_API_KEY_HEADER"),
+	}
+
+	if auth.Token != "" && (auth.Username != "" || auth.Password != "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Conflicting Authentication",
+			"token cannot be combined with username and password, both use the Authorization header.",
+		)
+	}
+
+	if (auth.Username == "") != (auth.Password == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Incomplete Basic Authentication",
+			"username and password must be set together.",
+		)
+	}
+
+	var allowedEmailDomains []string
+	if !data.AllowedEmailDomains.IsNull() {
+		resp.Diagnostics.Append(data.AllowedEmailDomains.ElementsAs(ctx, &allowedEmailDomains, false)...)
+	} else if raw := os.Getenv("DEVOPS_BOOTCAMP_ALLOWED_EMAIL_DOMAINS"); raw != "" {
+		for _, domain := range strings.Split(raw, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				allowedEmailDomains = append(allowedEmailDomains, domain)
+			}
+		}
+	}
+
+	nameConflictSeverity := stringValueOrEnv(data.NameConflictSeverity, "DEVOPS_BOOTCAMP_NAME_CONFLICT_SEVERITY")
+	if nameConflictSeverity != "" && nameConflictSeverity != nameConflictError && nameConflictSeverity != nameConflictWarning {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_conflict_severity"),
+			"Invalid Name Conflict Severity",
+			fmt.Sprintf("name_conflict_severity must be %q or %q, got %q.", nameConflictError, nameConflictWarning, nameConflictSeverity),
+		)
+	}
+
+	skipHealthCheck, _ := boolValueOrEnv(data.SkipHealthCheck, "DEVOPS_BOOTCAMP_SKIP_HEALTH_CHECK", path.Root("skip_health_check"), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Example client configuration for data sources and resources
-	client, err := NewClient(&host)
+	client, err := NewClient(&endpoint)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -71,14 +261,182 @@ func (p *DevOpsBootcampProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
+	client.Retry = retry
+	client.HTTPClient.Timeout = requestTimeout
+	client.Auth = auth
+	client.AllowedEmailDomains = allowedEmailDomains
+	client.WarnOnNameConflict = nameConflictSeverity == nameConflictWarning
+
+	if !skipHealthCheck {
+		err = client.CheckHealth(ctx)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Reach the DevOps Bootcamp API",
+				fmt.Sprintf("The health check against %s failed: %s. "+
+					"Verify the endpoint and credentials, or set skip_health_check to true to configure the provider without contacting the API.", endpoint, err),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("connected to DevOps Bootcamp API version %q", client.APIVersion))
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// hasUnknownValue reports whether any provider attribute is unknown.
+func hasUnknownValue(data DevOpsBootcampProviderModel) bool {
+	values := []attr.Value{
+		data.Endpoint,
+		data.MaxRetries,
+		data.RetryMaxWait,
+		data.RequestTimeout,
+		data.Token,
+		data.Username,
+		data.Password,
+		data.APIKey,
+		data.APIKeyHeader,
+		data.SkipHealthCheck,
+		data.AllowedEmailDomains,
+		data.NameConflictSeverity,
+	}
+
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientRefreshable reports whether a resource Read can refresh its state.
+// While Configure leaves the client unset the prior state is kept instead, so
+// plans of a provider configured from other resources' outputs still work.
+func clientRefreshable(ctx context.Context, client *Client) bool {
+	if client != nil {
+		return true
+	}
+
+	tflog.Debug(ctx, "provider configuration not known yet, keeping the prior state")
+
+	return false
+}
+
+// clientConfigured reports whether client is set, adding an error to diags
+// when it is not. Configure leaves the client unset while the provider
+// configuration has unknown values, so resources must not change anything and
+// data sources cannot produce their values until the configuration is known.
+func clientConfigured(client *Client, diags *diag.Diagnostics) bool {
+	if client != nil {
+		return true
+	}
+
+	diags.AddError(
+		"Provider Not Configured",
+		"The provider configuration depends on values that are not known yet, such as attributes of resources that have not been created. "+
+			"Create those resources first, for example with -target, or configure the provider with known values.",
+	)
+
+	return false
+}
+
+// parseEndpoint validates that endpoint is an absolute http(s) URL and
+// returns it without a trailing slash, ready to have API paths appended.
+func parseEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("host is missing")
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("query and fragment are not supported")
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// stringValueOrEnv returns the configured value, falling back to the
+// environment variable env when the attribute is not set.
+func stringValueOrEnv(value types.String, env string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+
+	return os.Getenv(env)
+}
+
+// boolValueOrEnv returns the configured value, falling back to the
+// environment variable env when the attribute is not set. ok is false when
+// neither is set or the environment variable is not a boolean, in which case
+// an error is added to diags.
+func boolValueOrEnv(value types.Bool, env string, attrPath path.Path, diags *diag.Diagnostics) (bool, bool) {
+	if !value.IsNull() {
+		return value.ValueBool(), true
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return false, false
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("The %s environment variable must be a boolean, got %q.", env, raw),
+		)
+		return false, false
+	}
+
+	return parsed, true
+}
+
+// int64ValueOrEnv returns the configured value, falling back to the
+// environment variable env when the attribute is not set. ok is false when
+// neither is set or the environment variable is not an integer, in which case
+// an error is added to diags.
+func int64ValueOrEnv(value types.Int64, env string, attrPath path.Path, diags *diag.Diagnostics) (int64, bool) {
+	if !value.IsNull() {
+		return value.ValueInt64(), true
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return 0, false
+	}
+
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("The %s environment variable must be an integer, got %q.", env, raw),
+		)
+		return 0, false
+	}
+
+	return parsed, true
+}
+
 func (p *DevOpsBootcampProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewEngineerResource,
 		NewDevResource,
+		NewOpsResource,
+		NewDevOpsResource,
+		NewDevMembershipResource,
 	}
 }
 
@@ -86,6 +444,8 @@ func (p *DevOpsBootcampProvider) DataSources(ctx context.Context) []func() datas
 	return []func() datasource.DataSource{
 		NewEngineerDataSource,
 		NewDevDataSource,
+		NewEngineersDataSource,
+		NewDevsDataSource,
 	}
 }
 
@@ -4,23 +4,68 @@
 package provider
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-devops-bootcamp/internal/bootcampapi"
 )
 
-const (
+var (
+	// testAccEndpoint is the API the acceptance tests run against. TestMain
+	// starts an in-memory API unless DEVOPS_BOOTCAMP_ENDPOINT points at a
+	// running one.
+	testAccEndpoint string
+
+	// testAccServer is the in-memory API behind testAccEndpoint, nil when
+	// the tests run against an external API.
+	testAccServer *bootcampapi.Server
+
 	// providerConfig is a shared configuration to combine with the actual
-	// test configuration so the HashiCups client is properly configured.
-	// It is also possible to use the HASHICUPS_ environment variables instead,
-	// such as updating the Makefile and running the testing through that tool.
-	providerConfig = `
+	// test configuration so the client is configured against testAccEndpoint.
+	providerConfig string
+)
+
+func TestMain(m *testing.M) {
+	testAccEndpoint = os.Getenv("DEVOPS_BOOTCAMP_ENDPOINT")
+
+	// Sweeping a fresh in-memory API would silently find nothing to delete
+	flag.Parse()
+	if sweep := flag.Lookup("sweep"); sweep != nil && sweep.Value.String() != "" && testAccEndpoint == "" {
+		fmt.Fprintln(os.Stderr, "sweepers need DEVOPS_BOOTCAMP_ENDPOINT set to the API to clean up")
+		os.Exit(1)
+	}
+
+	if testAccEndpoint == "" {
+		testAccServer = bootcampapi.NewServer()
+		testAccEndpoint = httptest.NewServer(testAccServer).URL
+	}
+
+	providerConfig = testProviderConfig("")
+
+	// Runs the sweepers instead of the tests when -sweep is set. Either way
+	// it exits the process, which also stops the in-memory API.
+	resource.TestMain(m)
+}
+
+// testProviderConfig returns a provider block for testAccEndpoint with the
+// additional attributes.
+func testProviderConfig(attributes string) string {
+	return fmt.Sprintf(`
 provider "devops-bootcamp" {
-  endpoint = "http://localhost:8080"
+  endpoint = %q
+  %s
+}
+`, testAccEndpoint, attributes)
 }
-`
-)
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
@@ -30,8 +75,236 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"devops-bootcamp": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccPreCheckInMemoryAPI skips tests that need the in-memory API, such as
+// ones injecting faults, when running against an external API.
+func testAccPreCheckInMemoryAPI(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("needs the in-memory API, unset DEVOPS_BOOTCAMP_ENDPOINT")
+	}
+}
+
+// testAccInjectFault injects fault into the in-memory API until the end of
+// the test.
+func testAccInjectFault(t *testing.T, method string, prefix string, fault bootcampapi.Fault) {
+	testAccServer.InjectFault(method, prefix, fault)
+	t.Cleanup(testAccServer.ClearFaults)
+}
+
+// testAccClient returns a client for testAccEndpoint, authenticated with the
+// same environment variables as the provider.
+func testAccClient() (*Client, error) {
+	client, err := NewClient(&testAccEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Auth = AuthConfig{
+		Token:        os.Getenv("DEVOPS_BOOTCAMP_TOKEN"),
+		Username:     os.Getenv("DEVOPS_BOOTCAMP_USERNAME"),
+		Password:     os.Getenv("DEVOPS_BOOTCAMP_PASSWORD"),
+		APIKey:       os.Getenv("DEVOPS_BOOTCAMP_API_KEY"),
+		APIKeyHeader: os.Getenv("DEVOPS_BOOTCAMP_API_KEY_HEADER"),
+	}
+
+	return client, nil
+}
+
+// testAccWithClient calls fn with a client for testAccEndpoint.
+func testAccWithClient(fn func(ctx context.Context, client *Client) error) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	return fn(context.Background(), client)
+}
+
+// testAccDrift returns a PreConfig function that changes objects through the
+// API, behind Terraform's back, so the step can assert that Read detects the
+// drift.
+func testAccDrift(t *testing.T, drift func(ctx context.Context, client *Client) error) func() {
+	return func() {
+		if err := testAccWithClient(drift); err != nil {
+			t.Fatalf("unable to change objects outside of Terraform: %s", err)
+		}
+	}
+}
+
+// testAccCheckAPI returns a check asserting the state of objects as the API
+// reports it, rather than as Terraform stored it.
+func testAccCheckAPI(check func(ctx context.Context, client *Client) error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		return testAccWithClient(check)
+	}
+}
+
+// testAccCaptureId stores the id of the resource at address in id, for later
+// steps to change the object through the API.
+func testAccCaptureId(address string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", address)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestParseEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		"default":        {endpoint: "http://localhost:8080", want: "http://localhost:8080"},
+		"trailing slash": {endpoint: "https://bootcamp.example.com/api/", want: "https://bootcamp.example.com/api"},
+		"missing scheme": {endpoint: "localhost:8080", wantErr: true},
+		"bad scheme":     {endpoint: "ftp://localhost:8080", wantErr: true},
+		"missing host":   {endpoint: "http://", wantErr: true},
+		"query":          {endpoint: "http://localhost:8080?debug=true", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseEndpoint(tc.endpoint)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got %q", tc.endpoint, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProviderUnknownEndpoint(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"endpoint": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range configured.Diagnostics {
+		t.Errorf("unexpected configure diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// Refreshing with the client left unconfigured keeps the prior state, so
+	// existing resources can still be planned
+	engineerSchema := schemas.ResourceSchemas["devops-bootcamp_engineer"]
+	prior := testDynamicValue(t, engineerSchema, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "eng-1"),
+		"name":  tftypes.NewValue(tftypes.String, "Bobby"),
+		"email": tftypes.NewValue(tftypes.String, "bobby@bobby.com"),
+	})
+
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "devops-bootcamp_engineer",
+		CurrentState: prior,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range read.Diagnostics {
+		t.Errorf("unexpected read diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	priorValue, err := prior.Unmarshal(engineerSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newValue, err := read.NewState.Unmarshal(engineerSchema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !newValue.Equal(priorValue) {
+		t.Errorf("expected the prior state %s, got %s", priorValue, newValue)
+	}
+
+	// Changes cannot be applied without a client
+	noState, err := tfprotov6.NewDynamicValue(engineerSchema.ValueType(), tftypes.NewValue(engineerSchema.ValueType(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "devops-bootcamp_engineer",
+		PriorState:   &noState,
+		PlannedState: prior,
+		Config:       prior,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied.Diagnostics) != 1 || applied.Diagnostics[0].Summary != "Provider Not Configured" {
+		t.Errorf("expected a provider not configured error from the create, got %+v", applied.Diagnostics)
+	}
+
+	// Data sources have no prior values to fall back to
+	dataSource, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: "devops-bootcamp_engineers",
+		Config:   testDynamicValue(t, schemas.DataSourceSchemas["devops-bootcamp_engineers"], nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dataSource.Diagnostics) != 1 || dataSource.Diagnostics[0].Summary != "Provider Not Configured" {
+		t.Errorf("expected a provider not configured error from the data source, got %+v", dataSource.Diagnostics)
+	}
+}
+
+// testObjectValue returns a value of objectType with every attribute null
+// except the given ones.
+func testObjectValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// testDynamicValue returns a value of the schema's type for protocol
+// requests, with every attribute null except the given ones.
+func testDynamicValue(t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+
+	value, err := tfprotov6.NewDynamicValue(objectType, testObjectValue(objectType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &value
+}
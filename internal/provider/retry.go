@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// idempotencyKeyHeader is sent with every POST. The API acknowledges that it
+// deduplicates the request by echoing the header back, which is the only case
+// in which a failed POST is retried.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// RetryConfig controls how DoRequest retries transient API failures.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retrying.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled on every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps both the computed backoff and any Retry-After sent by the API.
+	MaxDelay time.Duration
+	// Jitter randomizes each delay between half and all of its computed value.
+	Jitter bool
+}
+
+// DefaultRetryConfig is used by NewClient unless the provider configures otherwise.
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   30 * time.Second,
+	Jitter:     true,
+}
+
+// backoff returns the delay before retry number attempt, starting at 0.
+func (rc RetryConfig) backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(rc.BaseDelay) * math.Pow(2, float64(attempt)))
+	if delay <= 0 || delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+
+	if rc.Jitter && delay > 1 {
+		delay = delay/2 + time.Duration(mathrand.Int63n(int64(delay/2)+1))
+	}
+
+	return delay
+}
+
+// retryAfter returns the delay requested by the Retry-After header of res, if any.
+func (rc RetryConfig) retryAfter(res *http.Response) (time.Duration, bool) {
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+
+	return delay, true
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// canRetry reports whether req may be sent again. Requests other than POST
+// are idempotent in the bootcamp API; a POST is only retried when the API
+// acknowledged its idempotency key on the failed response.
+func canRetry(req *http.Request, res *http.Response) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+
+	key := req.Header.Get(idempotencyKeyHeader)
+
+	return res != nil && key != "" && res.Header.Get(idempotencyKeyHeader) == key
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+
+	return hex.EncodeToString(b)
+}
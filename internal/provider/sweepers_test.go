@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch6/devops-resources"
+)
+
+// testAccNamePrefix starts the name of every object the acceptance tests
+// create. Sweepers only delete objects whose name starts with it, so they
+// never touch real objects on a shared API.
+const testAccNamePrefix = "tf-acc-"
+
+// Sweepers run with `go test ./internal/provider -v -sweep=all` and clean up
+// the objects failed acceptance test runs leave behind on testAccEndpoint,
+// which TestMain requires to be set with DEVOPS_BOOTCAMP_ENDPOINT.
+// Groups are swept before the engineers in them, and devops before groups.
+func init() {
+	resource.AddTestSweepers("devops-bootcamp_devops", &resource.Sweeper{
+		Name: "devops-bootcamp_devops",
+		F:    sweepDevOps,
+	})
+
+	resource.AddTestSweepers("devops-bootcamp_dev", &resource.Sweeper{
+		Name:         "devops-bootcamp_dev",
+		Dependencies: []string{"devops-bootcamp_devops"},
+		F:            sweepDevs,
+	})
+
+	resource.AddTestSweepers("devops-bootcamp_ops", &resource.Sweeper{
+		Name:         "devops-bootcamp_ops",
+		Dependencies: []string{"devops-bootcamp_devops"},
+		F:            sweepOps,
+	})
+
+	resource.AddTestSweepers("devops-bootcamp_engineer", &resource.Sweeper{
+		Name:         "devops-bootcamp_engineer",
+		Dependencies: []string{"devops-bootcamp_dev", "devops-bootcamp_ops"},
+		F:            sweepEngineers,
+	})
+}
+
+// isSweepable reports whether name was created by the acceptance tests.
+func isSweepable(name string) bool {
+	return strings.HasPrefix(name, testAccNamePrefix)
+}
+
+// ignoreNotFound treats objects deleted by something else meanwhile as swept.
+func ignoreNotFound(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// sweepDevOps deletes the devops that pair any group the dev or ops sweepers
+// are about to delete, as devops have no name of their own.
+func sweepDevOps(region string) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	all, err := client.ListDevOps(ctx)
+	if err != nil {
+		return fmt.Errorf("listing devops: %w", err)
+	}
+
+	var errs []string
+	for _, devops := range all {
+		if !devOpsIsSweepable(devops) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping devops %s", devops.Id)
+
+		if err := ignoreNotFound(client.DeleteDevOps(ctx, devops)); err != nil {
+			errs = append(errs, fmt.Sprintf("devops %s: %s", devops.Id, err))
+		}
+	}
+
+	return sweepErrors(errs)
+}
+
+func devOpsIsSweepable(devops *devops_resource.DevOps) bool {
+	for _, dev := range devops.Devs {
+		if isSweepable(dev.Name) {
+			return true
+		}
+	}
+
+	for _, ops := range devops.Ops {
+		if isSweepable(ops.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sweepDevs(region string) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	devs, err := client.ListDevs(ctx)
+	if err != nil {
+		return fmt.Errorf("listing devs: %w", err)
+	}
+
+	var errs []string
+	for _, dev := range devs {
+		if !isSweepable(dev.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping dev %s (%s)", dev.Name, dev.Id)
+
+		if err := ignoreNotFound(client.DeleteDev(ctx, dev)); err != nil {
+			errs = append(errs, fmt.Sprintf("dev %s: %s", dev.Id, err))
+		}
+	}
+
+	return sweepErrors(errs)
+}
+
+func sweepOps(region string) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	all, err := client.ListOps(ctx)
+	if err != nil {
+		return fmt.Errorf("listing ops: %w", err)
+	}
+
+	var errs []string
+	for _, ops := range all {
+		if !isSweepable(ops.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping ops %s (%s)", ops.Name, ops.Id)
+
+		if err := ignoreNotFound(client.DeleteOps(ctx, ops)); err != nil {
+			errs = append(errs, fmt.Sprintf("ops %s: %s", ops.Id, err))
+		}
+	}
+
+	return sweepErrors(errs)
+}
+
+func sweepEngineers(region string) error {
+	client, err := testAccClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	engineers, err := client.ListEngineers(ctx)
+	if err != nil {
+		return fmt.Errorf("listing engineers: %w", err)
+	}
+
+	var errs []string
+	for _, engineer := range engineers {
+		if !isSweepable(engineer.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] sweeping engineer %s (%s)", engineer.Name, engineer.Id)
+
+		if err := ignoreNotFound(client.DeleteEngineer(ctx, engineer)); err != nil {
+			errs = append(errs, fmt.Sprintf("engineer %s: %s", engineer.Id, err))
+		}
+	}
+
+	return sweepErrors(errs)
+}
+
+// sweepErrors combines the errors of a sweeper, so one object that cannot be
+// deleted does not stop the others from being swept.
+func sweepErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("sweeping failed for %s", strings.Join(errs, "; "))
+}
+
+func TestSweepers(t *testing.T) {
+	testAccPreCheckInMemoryAPI(t)
+
+	client, err := testAccClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	leaked, err := client.CreateEngineer(ctx, "tf-acc-BobbyLeaked", "leaked@bobby.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Real objects may share a name with test fixtures, just not the prefix
+	kept, err := client.CreateEngineer(ctx, "Bobby", "bobby@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.DeleteEngineer(ctx, kept) }()
+
+	dev, err := client.CreateDev(ctx, &devops_resource.Dev{Name: "tf-acc-updatedBobbyLeaked", Engineers: []*devops_resource.Engineer{{Id: leaked.Id}, {Id: kept.Id}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ops, err := client.CreateOps(ctx, &devops_resource.Ops{Name: "KeeperOps", Engineers: []*devops_resource.Engineer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.DeleteOps(ctx, ops) }()
+
+	devops, err := client.CreateDevOps(ctx, &devops_resource.DevOps{Devs: []*devops_resource.Dev{{Id: dev.Id}}, Ops: []*devops_resource.Ops{{Id: ops.Id}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sweep := range []resource.SweeperFunc{sweepDevOps, sweepDevs, sweepOps, sweepEngineers} {
+		if err := sweep(""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := client.GetDevOpsById(ctx, devops.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected devops pairing a test dev to be swept, got %v", err)
+	}
+	if _, err := client.GetDevById(ctx, dev.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected test dev to be swept, got %v", err)
+	}
+	if _, err := client.GetEngineer(ctx, leaked.Id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected test engineer to be swept, got %v", err)
+	}
+	if _, err := client.GetOpsById(ctx, ops.Id); err != nil {
+		t.Errorf("expected other ops to be kept, got %v", err)
+	}
+	if _, err := client.GetEngineer(ctx, kept.Id); err != nil {
+		t.Errorf("expected other engineer to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Default operation timeouts used when a resource has no timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// timeoutExceeded reports whether ctx hit its deadline and, if so, adds an
+// error diagnostic naming the operation and how long it ran for.
+func timeoutExceeded(ctx context.Context, diags *diag.Diagnostics, operation string, timeout time.Duration, start time.Time) bool {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	diags.AddError(
+		fmt.Sprintf("Timeout %s", operation),
+		fmt.Sprintf("%s did not complete within the %s timeout, gave up after %s. "+
+			"Increase the timeout in the resource's timeouts block if the API needs more time.",
+			operation, timeout, time.Since(start).Round(time.Millisecond)),
+	)
+
+	return true
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = emailValidator{}
+
+// emailValidator validates that a string is a bare RFC 5322 address such as
+// "ryan@example.com", without a display name or angle brackets.
+type emailValidator struct{}
+
+func (v emailValidator) Description(ctx context.Context) string {
+	return "value must be a valid email address"
+}
+
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value || address.Name != "" {
+		detail := "Expected an address such as ryan@example.com"
+		if err != nil {
+			detail = fmt.Sprintf("%s: %s", detail, err)
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Email Address",
+			fmt.Sprintf("%q is not a valid email address. %s.", value, detail),
+		)
+	}
+}
+
+// emailDomainAllowed reports whether the domain of email is one of domains,
+// compared case insensitively.
+func emailDomainAllowed(email string, domains []string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := email[at+1:]
+	for _, allowed := range domains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addNameConflict reports that the planned name of a kind object is already
+// used by the objects with ids, as a warning when warn is set.
+func addNameConflict(diags *diag.Diagnostics, warn bool, kind string, name string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	summary := "Duplicate " + strings.ToUpper(kind[:1]) + kind[1:] + " Name"
+	detail := fmt.Sprintf("A %s named %q already exists with id %s and is not managed by this resource. "+
+		"Lookups by name, such as the %s data source, cannot tell them apart. "+
+		"Choose a different name, import the existing %s, or set name_conflict_severity to %q in the provider to allow it.",
+		kind, name, strings.Join(ids, ", "), kind, kind, nameConflictWarning)
+
+	if warn {
+		diags.AddAttributeWarning(path.Root("name"), summary, detail)
+		return
+	}
+
+	diags.AddAttributeError(path.Root("name"), summary, detail)
+}
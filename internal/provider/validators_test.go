@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailValidator(t *testing.T) {
+	cases := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"valid":           {value: types.StringValue("ryan@example.com")},
+		"plus addressing": {value: types.StringValue("ryan+bootcamp@example.com")},
+		"null":            {value: types.StringNull()},
+		"unknown":         {value: types.StringUnknown()},
+		"missing at":      {value: types.StringValue("ryan.example.com"), wantError: true},
+		"missing domain":  {value: types.StringValue("ryan@"), wantError: true},
+		"display name":    {value: types.StringValue("Ryan <ryan@example.com>"), wantError: true},
+		"whitespace":      {value: types.StringValue(" ryan@example.com"), wantError: true},
+		"empty":           {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("email"), ConfigValue: tc.value}
+			resp := &validator.StringResponse{}
+
+			emailValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error %t, got diagnostics: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEmailDomainAllowed(t *testing.T) {
+	domains := []string{"example.com", "@liatrio.com"}
+
+	cases := map[string]bool{
+		"ryan@example.com":     true,
+		"ryan@EXAMPLE.com":     true,
+		"ryan@liatrio.com":     true,
+		"ryan@sub.example.com": false,
+		"ryan@example.org":     false,
+		"ryan":                 false,
+	}
+
+	for email, want := range cases {
+		if got := emailDomainAllowed(email, domains); got != want {
+			t.Errorf("emailDomainAllowed(%q): expected %t, got %t", email, want, got)
+		}
+	}
+}
+
+func TestAddNameConflict(t *testing.T) {
+	var diags diag.Diagnostics
+
+	addNameConflict(&diags, false, "engineer", "Ryan", nil)
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics without conflicting ids, got: %v", diags)
+	}
+
+	addNameConflict(&diags, false, "engineer", "Ryan", []string{"1"})
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Duplicate Engineer Name" {
+		t.Fatalf("expected a Duplicate Engineer Name error, got: %v", diags)
+	}
+
+	diags = nil
+	addNameConflict(&diags, true, "dev", "Team", []string{"1", "2"})
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got: %v", diags)
+	}
+}